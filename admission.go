@@ -0,0 +1,143 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// utilizationMaxAge is how long admit trusts LatestCPU and LatestMem before
+// sampling them again. CPU is measured since the previous sample, which is
+// meaningless over a few milliseconds.
+const utilizationMaxAge = time.Second
+
+// SaturatedError is returned by StartJob when the worker is over one of its
+// CpuThresh, MemThresh or PowerThresh thresholds. It matches
+// ErrWorkerSaturated with errors.Is, also after crossing a transport.
+type SaturatedError struct {
+	// Resource is "cpu", "memory" or "power"
+	Resource string
+	Value    float32
+	Thresh   float32
+}
+
+func (e *SaturatedError) Error() string {
+	return fmt.Sprintf("%s: %s at %.2f, threshold %.2f", ErrWorkerSaturated, e.Resource, e.Value, e.Thresh)
+}
+
+func (e *SaturatedError) Is(target error) bool {
+	return target == ErrWorkerSaturated
+}
+
+// parseSaturatedError reverses SaturatedError.Error.
+func parseSaturatedError(msg string) (*SaturatedError, bool) {
+	e := &SaturatedError{}
+	format := ErrWorkerSaturated.Error() + ": %s at %f, threshold %f"
+	if n, err := fmt.Sscanf(msg, format, &e.Resource, &e.Value, &e.Thresh); err != nil || n != 3 {
+		return nil, false
+	}
+	return e, true
+}
+
+// recordUtilization keeps LatestCPU, LatestMem and the power fields in line
+// with the stats last read from the machine, and adds the predicted power
+// to stats as "predictedPower" along with the meter readings.
+func (w *ServerWorker) recordUtilization(stats map[string]interface{}) {
+	if cpu, ok := toFloat(stats["cpupercent"]); ok {
+		w.LatestCPU = float32(cpu)
+	}
+	if mem, ok := toFloat(stats["vmem"]); ok {
+		w.LatestMem = float32(mem)
+	}
+	w.utilMu.Lock()
+	w.utilAt = time.Now()
+	w.utilMu.Unlock()
+	if m := w.currentPowerModel(); m != nil {
+		if watts, err := m.Predict(stats); err != nil {
+			log.Print(err)
+		} else {
+			w.LatestPredictedPower = watts
+			stats["predictedPower"] = float64(watts)
+		}
+	}
+	w.recordPower(stats)
+}
+
+// currentPower is the measured power while the meter runs and its latest
+// reading is recent, and the predicted power otherwise.
+func (w *ServerWorker) currentPower() float32 {
+	if r, ok := w.liveReading(); ok {
+		return r.Watts
+	}
+	return w.LatestPredictedPower
+}
+
+// sampleUtilization updates LatestCPU and LatestMem unless they are fresh.
+// Unlike profile.GetCPUAndMemStats, which measures CPU over a second, it
+// does not block: CPU is measured since the previous sample.
+func (w *ServerWorker) sampleUtilization(ctx context.Context) error {
+	w.utilMu.Lock()
+	defer w.utilMu.Unlock()
+	if time.Since(w.utilAt) < utilizationMaxAge {
+		return nil
+	}
+	percent, err := cpu.PercentWithContext(ctx, 0, false)
+	if err != nil {
+		return err
+	}
+	vmem, err := mem.VirtualMemoryWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	w.LatestCPU = float32(percent[0])
+	w.LatestMem = float32(vmem.UsedPercent)
+	w.utilAt = time.Now()
+
+	// models over other stats, e.g. freq, are left to Stats
+	stats := map[string]interface{}{"cpupercent": percent[0], "vmem": vmem.UsedPercent}
+	if m := w.currentPowerModel(); m != nil {
+		if watts, err := m.Predict(stats); err == nil {
+			w.LatestPredictedPower = watts
+		}
+	}
+	return nil
+}
+
+// admit refuses new jobs while the worker is over any of its thresholds.
+// A threshold of 0 is not enforced.
+func (w *ServerWorker) admit(ctx context.Context) error {
+	if err := w.sampleUtilization(ctx); err != nil {
+		return err
+	}
+
+	if w.CpuThresh > 0 && w.LatestCPU >= w.CpuThresh {
+		return &SaturatedError{Resource: "cpu", Value: w.LatestCPU, Thresh: w.CpuThresh}
+	}
+	if w.MemThresh > 0 && w.LatestMem >= w.MemThresh {
+		return &SaturatedError{Resource: "memory", Value: w.LatestMem, Thresh: w.MemThresh}
+	}
+	if power := w.currentPower(); w.PowerThresh > 0 && power >= w.PowerThresh {
+		return &SaturatedError{Resource: "power", Value: power, Thresh: w.PowerThresh}
+	}
+	return nil
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	}
+	return 0, false
+}
@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseSaturatedError(t *testing.T) {
+	tests := []struct {
+		name   string
+		msg    string
+		want   *SaturatedError
+		wantOK bool
+	}{
+		{"cpu", (&SaturatedError{Resource: "cpu", Value: 95.5, Thresh: 90}).Error(), &SaturatedError{Resource: "cpu", Value: 95.5, Thresh: 90}, true},
+		{"memory", (&SaturatedError{Resource: "memory", Value: 80, Thresh: 75.25}).Error(), &SaturatedError{Resource: "memory", Value: 80, Thresh: 75.25}, true},
+		{"power", (&SaturatedError{Resource: "power", Value: 250, Thresh: 200}).Error(), &SaturatedError{Resource: "power", Value: 250, Thresh: 200}, true},
+		{"bare", ErrWorkerSaturated.Error(), nil, false},
+		{"other", ErrWorkerDraining.Error(), nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseSaturatedError(tt.msg)
+			if ok != tt.wantOK {
+				t.Fatalf("parseSaturatedError(%q) ok = %v, want %v", tt.msg, ok, tt.wantOK)
+			}
+			if ok && *got != *tt.want {
+				t.Errorf("parseSaturatedError(%q) = %+v, want %+v", tt.msg, *got, *tt.want)
+			}
+		})
+	}
+
+	var saturated *SaturatedError
+	if err := remoteError(tests[0].msg); !errors.As(err, &saturated) || *saturated != *tests[0].want {
+		t.Errorf("remoteError() = %v, want a *SaturatedError", err)
+	}
+}
+
+func TestCurrentPower(t *testing.T) {
+	tests := []struct {
+		name    string
+		running bool
+		age     time.Duration
+		want    float32
+	}{
+		{"recent reading", true, 0, 120},
+		{"stale reading", true, 10 * time.Second, 60},
+		{"stopped meter", false, 0, 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meter := NewFakePowerMeter(120, time.Second)
+			if err := meter.Start(); err != nil {
+				t.Fatal(err)
+			}
+			meter.Record(PowerReading{Time: time.Now().Add(-tt.age), Watts: 120})
+			if !tt.running {
+				meter.Stop()
+			} else {
+				defer meter.Stop()
+			}
+			w := &ServerWorker{}
+			w.meter = meter
+			w.HasPowerMeter = true
+			w.LatestPredictedPower = 60
+
+			if got := w.currentPower(); got != tt.want {
+				t.Errorf("currentPower() = %g, want %g", got, tt.want)
+			}
+			stats := map[string]interface{}{}
+			w.recordPower(stats)
+			if _, ok := stats["actualPower"]; ok != tt.running {
+				t.Errorf("recordPower() reported actualPower %v, want %v", ok, tt.running)
+			}
+		})
+	}
+}
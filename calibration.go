@@ -0,0 +1,161 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+var errNoPowerModel = errors.New("worker has no power model")
+
+// minCalibrationSamples is how many paired samples calibration waits for
+// before fitting a model.
+const minCalibrationSamples = 10
+
+// CalibrationConfig turns on fitting a RegressionPowerModel online from the
+// readings of the worker's power meter.
+type CalibrationConfig struct {
+	// Window is how many of the latest paired samples the model is fit
+	// over, at least minCalibrationSamples. Calibration is off when it is
+	// 0.
+	Window int `json:"window"`
+	// Features are the stats the model is fit on, cpupercent when empty
+	Features []string `json:"features,omitempty"`
+	// Path is where the fitted model is saved, in the format of the
+	// powerModel config field, and loaded from on startup.
+	Path string `json:"path,omitempty"`
+}
+
+// calibrator pairs the stats returned by Stats with the meter readings taken
+// at the same time, and refits the power model over a sliding window of
+// those pairs.
+type calibrator struct {
+	config  CalibrationConfig
+	mu      sync.Mutex
+	pending []TimedStats
+	samples []PowerSample
+}
+
+func newCalibrator(config CalibrationConfig) *calibrator {
+	if len(config.Features) == 0 {
+		config.Features = []string{"cpupercent"}
+	}
+	return &calibrator{config: config}
+}
+
+func (c *calibrator) record(stats map[string]interface{}) {
+	c.mu.Lock()
+	c.pending = append(c.pending, TimedStats{Time: time.Now(), Stats: stats})
+	c.mu.Unlock()
+}
+
+// fit pairs the pending stats with readings and refits the model, returning
+// nil while there are too few samples.
+func (c *calibrator) fit(readings []PowerReading) (*RegressionPowerModel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(readings) == 0 {
+		return nil, nil
+	}
+
+	// stats newer than the last reading may still get one
+	last := readings[len(readings)-1].Time
+	var ready, later []TimedStats
+	for _, s := range c.pending {
+		if s.Time.After(last) {
+			later = append(later, s)
+		} else {
+			ready = append(ready, s)
+		}
+	}
+	c.pending = later
+	c.samples = append(c.samples, PairPowerSamples(readings, ready, wattsupInterval)...)
+	if len(c.samples) > c.config.Window {
+		c.samples = c.samples[len(c.samples)-c.config.Window:]
+	}
+	if len(c.samples) < minCalibrationSamples || len(c.samples) <= len(c.config.Features) {
+		return nil, nil
+	}
+	return FitRegressionPowerModel(c.samples, c.config.Features)
+}
+
+// calibrate refits the power model from the live meter readings, installs
+// it and saves it to the calibration path when it changed.
+func (w *ServerWorker) calibrate(stats map[string]interface{}) {
+	if w.calibrator == nil || w.meter == nil || !w.meter.Running() {
+		return
+	}
+	w.calibrator.record(stats)
+	m, err := w.calibrator.fit(w.meter.Samples())
+	if err != nil {
+		log.Print(err)
+		return
+	}
+	if m == nil {
+		return
+	}
+	if cur, ok := w.currentPowerModel().(*RegressionPowerModel); ok && cur.equal(m) {
+		return
+	}
+	w.SetPowerModel(m)
+	if path := w.config.Calibration.Path; path != "" {
+		if err := SavePowerModel(path, m); err != nil {
+			log.Print(err)
+		}
+	}
+}
+
+// SavePowerModel writes m as JSON, so it can be loaded with LoadPowerModel
+// or pasted as the powerModel of another worker's config.
+func SavePowerModel(path string, m *RegressionPowerModel) error {
+	buf, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func LoadPowerModel(path string) (*RegressionPowerModel, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := &RegressionPowerModel{}
+	if err := json.Unmarshal(buf, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Regression expresses m as the equivalent RegressionPowerModel.
+func (m *LinearPowerModel) Regression() *RegressionPowerModel {
+	return &RegressionPowerModel{
+		Intercept:    float64(m.Idle),
+		Coefficients: map[string]float64{"cpupercent": float64(m.Max-m.Idle) / 100},
+	}
+}
+
+// GetPowerModel returns the model the worker predicts its power with, so a
+// model calibrated on a worker with a meter can be shipped to meter-less
+// workers of the same hardware.
+func (w *ServerWorker) GetPowerModel(ctx context.Context) (*RegressionPowerModel, error) {
+	switch m := w.currentPowerModel().(type) {
+	case *RegressionPowerModel:
+		return m, nil
+	case *LinearPowerModel:
+		return m.Regression(), nil
+	case nil:
+		return nil, errNoPowerModel
+	default:
+		return nil, fmt.Errorf("power model %T cannot be exported", m)
+	}
+}
@@ -0,0 +1,94 @@
+package worker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalibratorFit(t *testing.T) {
+	t0 := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(i int) time.Time {
+		return t0.Add(time.Duration(i) * time.Second)
+	}
+	// watts = 40 + 0.5 cpu
+	readings := func(n int) []PowerReading {
+		var r []PowerReading
+		for i := 0; i < n; i++ {
+			r = append(r, PowerReading{Time: at(i), Watts: float32(40 + 0.5*float64(i*7%100))})
+		}
+		return r
+	}
+	stats := func(from, to int) []TimedStats {
+		var s []TimedStats
+		for i := from; i < to; i++ {
+			s = append(s, TimedStats{Time: at(i), Stats: map[string]interface{}{"cpupercent": float64(i * 7 % 100)}})
+		}
+		return s
+	}
+
+	tests := []struct {
+		name        string
+		window      int
+		pending     []TimedStats
+		readings    []PowerReading
+		wantModel   bool
+		wantSamples int
+		wantPending int
+	}{
+		{"too few samples", 20, stats(0, 5), readings(5), false, 5, 0},
+		{"fit", 20, stats(0, 12), readings(12), true, 12, 0},
+		{"stats newer than the last reading wait", 20, stats(0, 14), readings(12), true, 12, 2},
+		{"only stats newer than the last reading", 20, stats(5, 14), readings(5), false, 0, 9},
+		{"window", 10, stats(0, 15), readings(15), true, 10, 0},
+		{"no readings", 20, stats(0, 12), nil, false, 0, 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCalibrator(CalibrationConfig{Window: tt.window})
+			c.pending = tt.pending
+			m, err := c.fit(tt.readings)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (m != nil) != tt.wantModel {
+				t.Fatalf("fit() = %+v, want a model %v", m, tt.wantModel)
+			}
+			if m != nil && (!approxEqual(m.Intercept, 40) || !approxEqual(m.Coefficients["cpupercent"], 0.5)) {
+				t.Errorf("fit() = %+v, want 40 W + 0.5 W per cpupercent", m)
+			}
+			if len(c.samples) != tt.wantSamples || len(c.pending) != tt.wantPending {
+				t.Errorf("fit() left %d samples and %d pending stats, want %d and %d", len(c.samples), len(c.pending), tt.wantSamples, tt.wantPending)
+			}
+		})
+	}
+
+	// pending stats are paired once their reading comes
+	c := newCalibrator(CalibrationConfig{Window: 20})
+	c.pending = stats(0, 14)
+	c.fit(readings(12))
+	if _, err := c.fit(readings(14)); err != nil || len(c.samples) != 14 || len(c.pending) != 0 {
+		t.Errorf("second fit() left %d samples and %d pending stats, error %v, want 14 and 0", len(c.samples), len(c.pending), err)
+	}
+}
+
+func TestInitPowerModelWindow(t *testing.T) {
+	tests := []struct {
+		window  int
+		wantErr bool
+	}{
+		{0, false},
+		{minCalibrationSamples - 1, true},
+		{minCalibrationSamples, false},
+		{600, false},
+	}
+	for _, tt := range tests {
+		w := &ServerWorker{}
+		w.config.Calibration.Window = tt.window
+		if err := w.initPowerModel(); (err != nil) != tt.wantErr {
+			t.Errorf("initPowerModel() with window %d error = %v, wantErr %v", tt.window, err, tt.wantErr)
+		}
+		if enabled := w.calibrator != nil; enabled != (tt.window > 0 && !tt.wantErr) {
+			t.Errorf("initPowerModel() with window %d calibrating %v", tt.window, enabled)
+		}
+	}
+}
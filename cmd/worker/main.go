@@ -0,0 +1,180 @@
+// Command worker loads a WorkerConfig and serves a worker over net/rpc,
+// gRPC or HTTP, depending on the config's rpcServer and grpcServer flags.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"net/rpc"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	worker "github.com/Nguyen-Hoa/worker"
+	"google.golang.org/grpc"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+func loadConfig(path string) (worker.WorkerConfig, error) {
+	var config worker.WorkerConfig
+	f, err := os.Open(path)
+	if err != nil {
+		return config, err
+	}
+	defer f.Close()
+	if err := json.NewDecoder(f).Decode(&config); err != nil {
+		return config, err
+	}
+	return config, nil
+}
+
+func main() {
+	configPath := flag.String("config", "config.json", "path to the worker config file")
+	flag.Parse()
+
+	config, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatalln("failed to load config:", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if config.RPCServer {
+		err = serveRPC(ctx, config)
+	} else if config.GRPCServer {
+		err = serveGRPC(ctx, config)
+	} else {
+		err = serveHTTP(ctx, config)
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func serveRPC(ctx context.Context, config worker.WorkerConfig) error {
+	if config.RPCPort == "" {
+		return errors.New("rpcServer is set but no rpcPort was provided")
+	}
+	w := &worker.RPCServerWorker{}
+	if err := w.Init(config); err != nil {
+		return err
+	}
+	startLoops(ctx, &w.ServerWorker, config)
+	if err := w.Register(rpc.DefaultServer); err != nil {
+		return err
+	}
+	rpc.HandleHTTP()
+
+	log.Printf("%s serving net/rpc on %s", config.Name, config.RPCPort)
+	return serve(ctx, &http.Server{Addr: config.RPCPort}, w)
+}
+
+func serveGRPC(ctx context.Context, config worker.WorkerConfig) error {
+	if config.GRPCPort == "" {
+		return errors.New("grpcServer is set but no grpcPort was provided")
+	}
+	w := &worker.GRPCServerWorker{}
+	if err := w.Init(config); err != nil {
+		return err
+	}
+	startLoops(ctx, &w.ServerWorker, config)
+	lis, err := net.Listen("tcp", config.GRPCPort)
+	if err != nil {
+		return err
+	}
+	srv := grpc.NewServer()
+	w.Register(srv)
+
+	log.Printf("%s serving gRPC on %s", config.Name, config.GRPCPort)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.Serve(lis)
+	}()
+
+	select {
+	case err := <-errc:
+		w.Shutdown()
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Println("shutting down...")
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		srv.Stop()
+	}
+	return w.Shutdown()
+}
+
+func serveHTTP(ctx context.Context, config worker.WorkerConfig) error {
+	if config.HTTPPort == "" {
+		return errors.New("no httpPort was provided")
+	}
+	w := &worker.ServerWorker{}
+	if err := w.Init(config); err != nil {
+		return err
+	}
+	startLoops(ctx, w, config)
+
+	log.Printf("%s serving HTTP on %s", config.Name, config.HTTPPort)
+	return serve(ctx, &http.Server{Addr: config.HTTPPort, Handler: w.Handler()}, w)
+}
+
+// startLoops runs the worker's background loops enabled by its config until
+// ctx is done.
+func startLoops(ctx context.Context, w *worker.ServerWorker, config worker.WorkerConfig) {
+	go func() {
+		if err := w.RunReconciler(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Print(err)
+		}
+	}()
+	if config.PowerThresh > 0 && config.PowerCap.Policy != "" {
+		go func() {
+			if err := w.RunPowerCap(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				log.Print(err)
+			}
+		}()
+	}
+}
+
+type shutdowner interface {
+	Shutdown() error
+}
+
+// serve runs srv until ctx is cancelled, then stops accepting requests and
+// shuts the worker down.
+func serve(ctx context.Context, srv *http.Server, w shutdowner) error {
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errc:
+		w.Shutdown()
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Println("shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Print(err)
+	}
+	return w.Shutdown()
+}
@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	job "github.com/Nguyen-Hoa/job"
+)
+
+// Drain stops the worker from accepting jobs while letting the running ones
+// finish. StartJob fails with ErrWorkerDraining until Undrain is called.
+func (w *ServerWorker) Drain(ctx context.Context) error {
+	w.setDraining(ctx, true)
+	return nil
+}
+
+// Undrain lets a drained worker accept jobs again.
+func (w *ServerWorker) Undrain(ctx context.Context) error {
+	w.setDraining(ctx, false)
+	return nil
+}
+
+// setDraining turns drain mode on or off and returns the state the worker
+// is left in, for the handlers to report without reading State unlocked.
+func (w *ServerWorker) setDraining(ctx context.Context, draining bool) State {
+	w.stateMu.Lock()
+	w.draining = draining
+	w.stateMu.Unlock()
+	if draining {
+		log.Printf("%s: draining, %d jobs running", w.Name, len(w.jobIDs(&w.RunningJobs)))
+	}
+	return w.refreshState(ctx)
+}
+
+func (w *ServerWorker) isDraining() bool {
+	w.stateMu.Lock()
+	defer w.stateMu.Unlock()
+	return w.draining
+}
+
+// WaitDrained blocks until no jobs are left running on the worker or ctx is
+// done. It does not drain the worker itself.
+func (w *ServerWorker) WaitDrained(ctx context.Context) error {
+	return waitDrained(ctx, w.GetRunningJobs)
+}
+
+func (w *ManagerWorker) Drain(ctx context.Context) error {
+	if err := w.transport.Drain(ctx); err != nil {
+		log.Print(err)
+		return err
+	}
+	_, err := w.GetState(ctx)
+	return err
+}
+
+func (w *ManagerWorker) Undrain(ctx context.Context) error {
+	if err := w.transport.Undrain(ctx); err != nil {
+		log.Print(err)
+		return err
+	}
+	_, err := w.GetState(ctx)
+	return err
+}
+
+// WaitDrained blocks until no jobs are left running on the worker or ctx is
+// done, polling every waitPollInterval.
+func (w *ManagerWorker) WaitDrained(ctx context.Context) error {
+	return waitDrained(ctx, w.GetRunningJobs)
+}
+
+func waitDrained(ctx context.Context, running func(context.Context) (map[string]job.DockerJob, error)) error {
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+	for {
+		jobs, err := running(ctx)
+		if err != nil {
+			return err
+		}
+		if len(jobs) == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
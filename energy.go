@@ -0,0 +1,118 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+// jobUsage is what a job consumed over its lifetime, as far as sampled.
+type jobUsage struct {
+	// Energy is in joules
+	Energy    float64
+	CPUTime   time.Duration
+	MaxMemory uint64
+}
+
+// jobEnergy is the usage of a job so far, and the CPU counters it was last
+// sampled at.
+type jobEnergy struct {
+	jobUsage
+	usage  uint64
+	system uint64
+	read   time.Time
+}
+
+// energyAccount attributes the machine's power to its jobs by their share
+// of the machine's CPU time between two samples of their docker stats.
+type energyAccount struct {
+	mu   sync.Mutex
+	jobs map[string]*jobEnergy
+}
+
+func (a *energyAccount) init() {
+	a.jobs = make(map[string]*jobEnergy)
+}
+
+// add charges the job for its share of watts since its previous sample.
+// The first sample of a job only sets its counters.
+func (a *energyAccount) add(ID string, stats types.StatsJSON, watts float32) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	usage, system := stats.CPUStats.CPUUsage.TotalUsage, stats.CPUStats.SystemUsage
+	e, ok := a.jobs[ID]
+	if !ok {
+		e = &jobEnergy{usage: usage, system: system, read: stats.Read}
+		a.jobs[ID] = e
+	} else if system > e.system && usage >= e.usage && stats.Read.After(e.read) {
+		share := float64(usage-e.usage) / float64(system-e.system)
+		e.Energy += float64(watts) * stats.Read.Sub(e.read).Seconds() * share
+	}
+	e.usage, e.system, e.read = usage, system, stats.Read
+	// docker reports the CPU time of the container in nanoseconds
+	e.CPUTime = time.Duration(usage)
+	if mem := stats.MemoryStats.MaxUsage; mem > e.MaxMemory {
+		e.MaxMemory = mem
+	}
+	if mem := stats.MemoryStats.Usage; mem > e.MaxMemory {
+		e.MaxMemory = mem
+	}
+}
+
+// remove stops accounting for a job and returns its totals.
+func (a *energyAccount) remove(ID string) jobUsage {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var usage jobUsage
+	if e, ok := a.jobs[ID]; ok {
+		usage = e.jobUsage
+		delete(a.jobs, ID)
+	}
+	return usage
+}
+
+// keep stops accounting for the jobs not in IDs, which have finished.
+func (a *energyAccount) keep(IDs []string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	running := make(map[string]bool, len(IDs))
+	for _, id := range IDs {
+		running[id] = true
+	}
+	for id := range a.jobs {
+		if !running[id] {
+			delete(a.jobs, id)
+		}
+	}
+}
+
+// accountEnergy charges a job for the power drawn since it was last sampled,
+// given its raw docker stats.
+func (w *ServerWorker) accountEnergy(ID string, raw []byte) {
+	var stats types.StatsJSON
+	if err := json.Unmarshal(raw, &stats); err != nil {
+		log.Print(err)
+		return
+	}
+	w.energy.add(ID, stats, w.currentPower())
+}
+
+// sampleEnergy fetches the stats of a single job to account its energy.
+func (w *ServerWorker) sampleEnergy(ctx context.Context, ID string) {
+	stats, err := w._docker.ContainerStatsOneShot(ctx, ID)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+	defer stats.Body.Close()
+	var s types.StatsJSON
+	if err := json.NewDecoder(stats.Body).Decode(&s); err != nil {
+		log.Print(err)
+		return
+	}
+	w.energy.add(ID, s, w.currentPower())
+}
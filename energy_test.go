@@ -0,0 +1,92 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+func energyStats(usage, system uint64, read time.Time, mem uint64) types.StatsJSON {
+	var s types.StatsJSON
+	s.Read = read
+	s.CPUStats.CPUUsage.TotalUsage = usage
+	s.CPUStats.SystemUsage = system
+	s.MemoryStats.Usage = mem
+	return s
+}
+
+func TestEnergyAccountAdd(t *testing.T) {
+	t0 := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	first := energyStats(1e9, 10e9, t0, 100)
+	tests := []struct {
+		name    string
+		samples []types.StatsJSON
+		watts   float32
+		want    jobUsage
+	}{
+		{
+			name:    "first sample",
+			samples: []types.StatsJSON{first},
+			watts:   100,
+			want:    jobUsage{CPUTime: time.Second, MaxMemory: 100},
+		},
+		{
+			// a fifth of the machine's CPU time over 2s at 100 W
+			name:    "delta",
+			samples: []types.StatsJSON{first, energyStats(3e9, 20e9, t0.Add(2*time.Second), 50)},
+			watts:   100,
+			want:    jobUsage{Energy: 40, CPUTime: 3 * time.Second, MaxMemory: 100},
+		},
+		{
+			name:    "job counter reset",
+			samples: []types.StatsJSON{first, energyStats(0.5e9, 20e9, t0.Add(2*time.Second), 200)},
+			watts:   100,
+			want:    jobUsage{CPUTime: time.Second / 2, MaxMemory: 200},
+		},
+		{
+			name: "delta after a reset",
+			samples: []types.StatsJSON{
+				first,
+				energyStats(0.5e9, 20e9, t0.Add(2*time.Second), 100),
+				energyStats(2.5e9, 30e9, t0.Add(4*time.Second), 100),
+			},
+			watts: 100,
+			want:  jobUsage{Energy: 40, CPUTime: 2500 * time.Millisecond, MaxMemory: 100},
+		},
+		{
+			name:    "system counter not advancing",
+			samples: []types.StatsJSON{first, energyStats(3e9, 10e9, t0.Add(2*time.Second), 100)},
+			watts:   100,
+			want:    jobUsage{CPUTime: 3 * time.Second, MaxMemory: 100},
+		},
+		{
+			name:    "read not advancing",
+			samples: []types.StatsJSON{first, energyStats(3e9, 20e9, t0, 100)},
+			watts:   100,
+			want:    jobUsage{CPUTime: 3 * time.Second, MaxMemory: 100},
+		},
+		{
+			name:    "no power",
+			samples: []types.StatsJSON{first, energyStats(3e9, 20e9, t0.Add(2*time.Second), 100)},
+			watts:   0,
+			want:    jobUsage{CPUTime: 3 * time.Second, MaxMemory: 100},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var a energyAccount
+			a.init()
+			for _, s := range tt.samples {
+				a.add("job", s, tt.watts)
+			}
+			got := a.remove("job")
+			if !approxEqual(got.Energy, tt.want.Energy) || got.CPUTime != tt.want.CPUTime || got.MaxMemory != tt.want.MaxMemory {
+				t.Errorf("usage = %+v, want %+v", got, tt.want)
+			}
+			if again := a.remove("job"); again != (jobUsage{}) {
+				t.Errorf("usage after remove = %+v, want none", again)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package worker
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidJobSpec  = errors.New("invalid job spec")
+	ErrResourceLimit   = errors.New("resource request exceeds worker limits")
+	ErrWorkerSaturated = errors.New("worker saturated")
+	ErrWorkerDraining  = errors.New("worker draining")
+	ErrJobNotFound     = errors.New("job not found")
+)
+
+// knownErrors are the errors a worker returns that keep their identity on
+// the manager side. Transports only carry the message, so they are matched
+// by prefix, or after the ": " of an error wrapping them.
+var knownErrors = []error{
+	ErrInvalidJobSpec,
+	ErrResourceLimit,
+	ErrWorkerSaturated,
+	ErrWorkerDraining,
+	ErrJobNotFound,
+}
+
+type remoteErr struct {
+	msg    string
+	target error
+}
+
+func (e *remoteErr) Error() string {
+	return e.msg
+}
+
+func (e *remoteErr) Unwrap() error {
+	return e.target
+}
+
+// remoteError rebuilds an error received from a worker, so callers can
+// still use errors.Is against the errors in knownErrors, and errors.As
+// with *SaturatedError.
+func remoteError(msg string) error {
+	if e, ok := parseSaturatedError(msg); ok {
+		return e
+	}
+	for _, target := range knownErrors {
+		if strings.HasPrefix(msg, target.Error()) || strings.Contains(msg, ": "+target.Error()) {
+			return &remoteErr{msg: msg, target: target}
+		}
+	}
+	return errors.New(msg)
+}
@@ -0,0 +1,36 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestRemoteError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"invalid spec", invalidJobSpec("image is required"), ErrInvalidJobSpec},
+		{"resource limit", fmt.Errorf("%w: 4.00 cpus requested", ErrResourceLimit), ErrResourceLimit},
+		{"draining", ErrWorkerDraining, ErrWorkerDraining},
+		{"saturated", &SaturatedError{Resource: "cpu", Value: 95.5, Thresh: 90}, ErrWorkerSaturated},
+		{"not found", fmt.Errorf("%w: abc", ErrJobNotFound), ErrJobNotFound},
+		{"wrapped not found", fmt.Errorf("failed to stop: %w", ErrJobNotFound), ErrJobNotFound},
+		{"unknown", errors.New("docker unreachable"), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := remoteError(tt.err.Error())
+			if got.Error() != tt.err.Error() {
+				t.Errorf("remoteError() = %q, want %q", got, tt.err)
+			}
+			for _, target := range knownErrors {
+				if is := errors.Is(got, target); is != (target == tt.want) {
+					t.Errorf("errors.Is(remoteError(), %v) = %v", target, is)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package worker
+
+import (
+	"context"
+	"io"
+
+	job "github.com/Nguyen-Hoa/job"
+	"github.com/Nguyen-Hoa/worker/workerpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GRPCTransport talks to a worker registered with GRPCServerWorker.Register.
+type GRPCTransport struct {
+	conn   *grpc.ClientConn
+	client workerpb.WorkerClient
+}
+
+func NewGRPCTransport(conn *grpc.ClientConn) *GRPCTransport {
+	return &GRPCTransport{conn: conn, client: workerpb.NewWorkerClient(conn)}
+}
+
+// DialGRPC connects to a worker serving gRPC at address, without TLS.
+func DialGRPC(address string) (*GRPCTransport, error) {
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return NewGRPCTransport(conn), nil
+}
+
+// grpcError strips the status code gRPC prefixes to errors returned by the
+// worker, so they read and match the same as over the other transports.
+func grpcError(err error) error {
+	if s, ok := status.FromError(err); ok {
+		return remoteError(s.Message())
+	}
+	return err
+}
+
+func (t *GRPCTransport) StartJob(ctx context.Context, j JobSpec) (string, error) {
+	reply, err := t.client.StartJob(ctx, &workerpb.StartJobRequest{Job: jobToProto(j)})
+	if err != nil {
+		return "", grpcError(err)
+	}
+	return reply.GetId(), nil
+}
+
+func (t *GRPCTransport) StopJob(ctx context.Context, ID string) (JobResult, error) {
+	reply, err := t.client.StopJob(ctx, &workerpb.StopJobRequest{Id: ID})
+	if err != nil {
+		return JobResult{}, grpcError(err)
+	}
+	return JobResult{
+		ID:           reply.GetId(),
+		TotalRunTime: reply.GetTotalRunTime().AsDuration(),
+		Energy:       reply.GetEnergy(),
+	}, nil
+}
+
+func (t *GRPCTransport) GetRunningJobs(ctx context.Context) (map[string]job.DockerJob, error) {
+	reply, err := t.client.GetRunningJobs(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	jobs := make(map[string]job.DockerJob, len(reply.GetJobs()))
+	for id, j := range reply.GetJobs() {
+		jobs[id] = runningJobFromProto(j)
+	}
+	return jobs, nil
+}
+
+func (t *GRPCTransport) GetRunningJobsStats(ctx context.Context) (map[string][]byte, error) {
+	reply, err := t.client.GetRunningJobsStats(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return reply.GetStats(), nil
+}
+
+func (t *GRPCTransport) Poll(ctx context.Context) (map[string]interface{}, error) {
+	reply, err := t.client.Poll(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return reply.AsMap(), nil
+}
+
+func (t *GRPCTransport) ReducedStats(ctx context.Context) (map[string]interface{}, error) {
+	reply, err := t.client.ReducedStats(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return reply.AsMap(), nil
+}
+
+func (t *GRPCTransport) StartMeter(ctx context.Context) error {
+	if _, err := t.client.StartMeter(ctx, &emptypb.Empty{}); err != nil {
+		return grpcError(err)
+	}
+	return nil
+}
+
+func (t *GRPCTransport) StopMeter(ctx context.Context) (string, error) {
+	reply, err := t.client.StopMeter(ctx, &emptypb.Empty{})
+	if err != nil {
+		return "", grpcError(err)
+	}
+	return reply.GetPath(), nil
+}
+
+func (t *GRPCTransport) GetState(ctx context.Context) (State, error) {
+	reply, err := t.client.IsAvailable(ctx, &emptypb.Empty{})
+	if err != nil {
+		return "", grpcError(err)
+	}
+	return State(reply.GetState()), nil
+}
+
+func (t *GRPCTransport) Drain(ctx context.Context) error {
+	if _, err := t.client.Drain(ctx, &emptypb.Empty{}); err != nil {
+		return grpcError(err)
+	}
+	return nil
+}
+
+func (t *GRPCTransport) Undrain(ctx context.Context) error {
+	if _, err := t.client.Undrain(ctx, &emptypb.Empty{}); err != nil {
+		return grpcError(err)
+	}
+	return nil
+}
+
+func (t *GRPCTransport) GetPowerModel(ctx context.Context) (*RegressionPowerModel, error) {
+	reply, err := t.client.GetPowerModel(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &RegressionPowerModel{Intercept: reply.GetIntercept(), Coefficients: reply.GetCoefficients()}, nil
+}
+
+func (t *GRPCTransport) MeterLog(ctx context.Context, q MeterLogQuery) ([]PowerReading, error) {
+	req := &workerpb.MeterLogRequest{Path: q.Path}
+	if !q.From.IsZero() {
+		req.From = timestamppb.New(q.From)
+	}
+	if !q.To.IsZero() {
+		req.To = timestamppb.New(q.To)
+	}
+	stream, err := t.client.MeterLog(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	var readings []PowerReading
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			return readings, nil
+		}
+		if err != nil {
+			return nil, grpcError(err)
+		}
+		readings = append(readings, PowerReading{Time: r.GetTime().AsTime(), Watts: r.GetWatts()})
+	}
+}
+
+func (t *GRPCTransport) PowerCapActions(ctx context.Context) ([]PowerCapAction, error) {
+	reply, err := t.client.PowerCapActions(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	actions := make([]PowerCapAction, len(reply.GetActions()))
+	for i, a := range reply.GetActions() {
+		actions[i] = PowerCapAction{
+			Time:   a.GetTime().AsTime(),
+			JobID:  a.GetJobId(),
+			Action: a.GetAction(),
+			Power:  a.GetPower(),
+			Thresh: a.GetThresh(),
+			CPUs:   a.GetCpus(),
+		}
+	}
+	return actions, nil
+}
+
+func (t *GRPCTransport) ListJobHistory(ctx context.Context, q HistoryQuery) ([]JobRecord, error) {
+	req := &workerpb.HistoryRequest{Reason: string(q.Reason), Limit: int32(q.Limit)}
+	if !q.Since.IsZero() {
+		req.Since = timestamppb.New(q.Since)
+	}
+	if !q.Until.IsZero() {
+		req.Until = timestamppb.New(q.Until)
+	}
+	reply, err := t.client.ListJobHistory(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	records := make([]JobRecord, len(reply.GetRecords()))
+	for i, r := range reply.GetRecords() {
+		records[i] = jobRecordFromProto(r)
+	}
+	return records, nil
+}
+
+func (t *GRPCTransport) JobStatus(ctx context.Context, ID string) (JobStatus, error) {
+	reply, err := t.client.JobStatus(ctx, &workerpb.JobStatusRequest{Id: ID})
+	if err != nil {
+		return JobStatus{}, grpcError(err)
+	}
+	s := JobStatus{ID: reply.GetId(), State: JobState(reply.GetState()), Reason: TerminationReason(reply.GetReason())}
+	if reply.ExitCode != nil {
+		code := int(reply.GetExitCode())
+		s.ExitCode = &code
+	}
+	return s, nil
+}
+
+func (t *GRPCTransport) PowerMeterOn(ctx context.Context) (bool, error) {
+	reply, err := t.client.PowerMeterOn(ctx, &emptypb.Empty{})
+	if err != nil {
+		return false, grpcError(err)
+	}
+	return reply.GetPowerMeterOn(), nil
+}
+
+func (t *GRPCTransport) Close() error {
+	return t.conn.Close()
+}
@@ -0,0 +1,34 @@
+package worker
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGRPCTransport(t *testing.T) {
+	w := &GRPCServerWorker{}
+	initTestWorker(t, &w.ServerWorker)
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	w.Register(server)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := NewGRPCTransport(conn)
+	defer tr.Close()
+	testTransport(t, tr)
+}
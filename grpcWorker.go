@@ -0,0 +1,330 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	job "github.com/Nguyen-Hoa/job"
+	"github.com/Nguyen-Hoa/worker/workerpb"
+	"github.com/docker/docker/api/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Register publishes the worker on server as the worker.Worker service
+// defined in workerpb/worker.proto.
+func (w *GRPCServerWorker) Register(server *grpc.Server) {
+	workerpb.RegisterWorkerServer(server, &grpcHandler{w: w})
+}
+
+// grpcHandler adapts GRPCServerWorker to the generated workerpb.WorkerServer.
+type grpcHandler struct {
+	workerpb.UnimplementedWorkerServer
+	w *GRPCServerWorker
+}
+
+func (h *grpcHandler) StartJob(ctx context.Context, req *workerpb.StartJobRequest) (*workerpb.StartJobReply, error) {
+	id, err := h.w.StartJob(ctx, jobFromProto(req.GetJob()))
+	if err != nil {
+		return nil, grpcStatus(err)
+	}
+	return &workerpb.StartJobReply{Id: id}, nil
+}
+
+func (h *grpcHandler) StopJob(ctx context.Context, req *workerpb.StopJobRequest) (*workerpb.StopJobReply, error) {
+	res, err := h.w.StopJob(ctx, req.GetId())
+	if err != nil {
+		return nil, grpcStatus(err)
+	}
+	return &workerpb.StopJobReply{Id: res.ID, TotalRunTime: durationpb.New(res.TotalRunTime), Energy: res.Energy}, nil
+}
+
+func (h *grpcHandler) GetRunningJobs(ctx context.Context, _ *emptypb.Empty) (*workerpb.RunningJobsReply, error) {
+	jobs, err := h.w.GetRunningJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply := &workerpb.RunningJobsReply{Jobs: make(map[string]*workerpb.RunningJob, len(jobs))}
+	for id, j := range jobs {
+		reply.Jobs[id] = runningJobToProto(j)
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) GetRunningJobsStats(ctx context.Context, _ *emptypb.Empty) (*workerpb.RunningJobsStatsReply, error) {
+	stats, err := h.w.GetRunningJobsStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &workerpb.RunningJobsStatsReply{Stats: stats}, nil
+}
+
+func (h *grpcHandler) Poll(ctx context.Context, _ *emptypb.Empty) (*structpb.Struct, error) {
+	stats, err := h.w.Stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return structpb.NewStruct(stats)
+}
+
+func (h *grpcHandler) ReducedStats(ctx context.Context, _ *emptypb.Empty) (*structpb.Struct, error) {
+	stats, err := h.w.ReducedStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return structpb.NewStruct(stats)
+}
+
+func (h *grpcHandler) StartMeter(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	if err := h.w.StartMeter(ctx); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *grpcHandler) StopMeter(ctx context.Context, _ *emptypb.Empty) (*workerpb.StopMeterReply, error) {
+	path, err := h.w.StopMeter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &workerpb.StopMeterReply{Path: path}, nil
+}
+
+func (h *grpcHandler) MeterLog(req *workerpb.MeterLogRequest, stream workerpb.Worker_MeterLogServer) error {
+	q := MeterLogQuery{Path: req.GetPath()}
+	if req.GetFrom() != nil {
+		q.From = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		q.To = req.GetTo().AsTime()
+	}
+	readings, err := h.w.MeterLog(stream.Context(), q)
+	if err != nil {
+		return err
+	}
+	for _, r := range readings {
+		if err := stream.Send(&workerpb.PowerReading{Time: timestamppb.New(r.Time), Watts: r.Watts}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *grpcHandler) IsAvailable(ctx context.Context, _ *emptypb.Empty) (*workerpb.IsAvailableReply, error) {
+	state, err := h.w.GetState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &workerpb.IsAvailableReply{Available: state.Accepting(), State: string(state)}, nil
+}
+
+func (h *grpcHandler) Drain(ctx context.Context, _ *emptypb.Empty) (*workerpb.IsAvailableReply, error) {
+	state := h.w.setDraining(ctx, true)
+	return &workerpb.IsAvailableReply{Available: state.Accepting(), State: string(state)}, nil
+}
+
+func (h *grpcHandler) Undrain(ctx context.Context, _ *emptypb.Empty) (*workerpb.IsAvailableReply, error) {
+	state := h.w.setDraining(ctx, false)
+	return &workerpb.IsAvailableReply{Available: state.Accepting(), State: string(state)}, nil
+}
+
+func (h *grpcHandler) GetPowerModel(ctx context.Context, _ *emptypb.Empty) (*workerpb.PowerModel, error) {
+	m, err := h.w.GetPowerModel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &workerpb.PowerModel{Intercept: m.Intercept, Coefficients: m.Coefficients}, nil
+}
+
+func (h *grpcHandler) PowerCapActions(ctx context.Context, _ *emptypb.Empty) (*workerpb.PowerCapActionsReply, error) {
+	actions, err := h.w.PowerCapActions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply := &workerpb.PowerCapActionsReply{Actions: make([]*workerpb.PowerCapAction, len(actions))}
+	for i, a := range actions {
+		reply.Actions[i] = &workerpb.PowerCapAction{
+			Time:   timestamppb.New(a.Time),
+			JobId:  a.JobID,
+			Action: a.Action,
+			Power:  a.Power,
+			Thresh: a.Thresh,
+			Cpus:   a.CPUs,
+		}
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) ListJobHistory(ctx context.Context, req *workerpb.HistoryRequest) (*workerpb.JobHistoryReply, error) {
+	q := HistoryQuery{Reason: TerminationReason(req.GetReason()), Limit: int(req.GetLimit())}
+	if req.GetSince() != nil {
+		q.Since = req.GetSince().AsTime()
+	}
+	if req.GetUntil() != nil {
+		q.Until = req.GetUntil().AsTime()
+	}
+	records, err := h.w.ListJobHistory(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	reply := &workerpb.JobHistoryReply{Records: make([]*workerpb.JobRecord, len(records))}
+	for i, r := range records {
+		reply.Records[i] = jobRecordToProto(r)
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) JobStatus(ctx context.Context, req *workerpb.JobStatusRequest) (*workerpb.JobStatusReply, error) {
+	s, err := h.w.JobStatus(ctx, req.GetId())
+	if err != nil {
+		return nil, grpcStatus(err)
+	}
+	reply := &workerpb.JobStatusReply{Id: s.ID, State: string(s.State), Reason: string(s.Reason)}
+	if s.ExitCode != nil {
+		code := int32(*s.ExitCode)
+		reply.ExitCode = &code
+	}
+	return reply, nil
+}
+
+func (h *grpcHandler) PowerMeterOn(ctx context.Context, _ *emptypb.Empty) (*workerpb.PowerMeterOnReply, error) {
+	return &workerpb.PowerMeterOnReply{PowerMeterOn: h.w.PowerMeterOn()}, nil
+}
+
+// grpcStatus attaches a status code to the errors callers can act on.
+func grpcStatus(err error) error {
+	switch {
+	case errors.Is(err, ErrInvalidJobSpec), errors.Is(err, ErrResourceLimit):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrWorkerSaturated):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, ErrWorkerDraining):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ErrJobNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return err
+	}
+}
+
+func jobToProto(j JobSpec) *workerpb.Job {
+	return &workerpb.Job{
+		Image:      j.Image,
+		Cmd:        j.Cmd,
+		Duration:   int64(j.Duration),
+		Env:        j.Env,
+		Volumes:    j.Volumes,
+		WorkingDir: j.WorkingDir,
+		User:       j.User,
+		Network:    j.Network,
+		Labels:     j.Labels,
+		Cpus:       j.CPUs,
+		CpusetCpus: j.CpusetCpus,
+		Memory:     j.Memory,
+	}
+}
+
+func jobFromProto(j *workerpb.Job) JobSpec {
+	return JobSpec{
+		Job: job.Job{
+			Image:    j.GetImage(),
+			Cmd:      j.GetCmd(),
+			Duration: int(j.GetDuration()),
+		},
+		Env:        j.GetEnv(),
+		Volumes:    j.GetVolumes(),
+		WorkingDir: j.GetWorkingDir(),
+		User:       j.GetUser(),
+		Network:    j.GetNetwork(),
+		Labels:     j.GetLabels(),
+		CPUs:       j.GetCpus(),
+		CpusetCpus: j.GetCpusetCpus(),
+		Memory:     j.GetMemory(),
+	}
+}
+
+func runningJobToProto(j job.DockerJob) *workerpb.RunningJob {
+	return &workerpb.RunningJob{
+		Id:           j.ID,
+		StartTime:    timestamppb.New(j.StartTime),
+		TotalRunTime: durationpb.New(j.TotalRunTime),
+		Duration:     durationpb.New(j.BaseJob.Duration),
+		Names:        j.Names,
+		Image:        j.Image,
+		Command:      j.Command,
+		Created:      j.Created,
+		Labels:       j.Labels,
+		State:        j.State,
+		Status:       j.Status,
+	}
+}
+
+func runningJobFromProto(j *workerpb.RunningJob) job.DockerJob {
+	var startTime time.Time
+	if j.GetStartTime() != nil {
+		startTime = j.GetStartTime().AsTime()
+	}
+	return job.DockerJob{
+		BaseJob: job.BaseJob{
+			StartTime:    startTime,
+			TotalRunTime: j.GetTotalRunTime().AsDuration(),
+			Duration:     j.GetDuration().AsDuration(),
+		},
+		Container: types.Container{
+			ID:      j.GetId(),
+			Names:   j.GetNames(),
+			Image:   j.GetImage(),
+			Command: j.GetCommand(),
+			Created: j.GetCreated(),
+			Labels:  j.GetLabels(),
+			State:   j.GetState(),
+			Status:  j.GetStatus(),
+		},
+	}
+}
+
+func jobRecordToProto(r JobRecord) *workerpb.JobRecord {
+	p := &workerpb.JobRecord{
+		Id:           r.ID,
+		JobId:        r.JobID,
+		Spec:         jobToProto(r.Spec),
+		StartTime:    timestamppb.New(r.StartTime),
+		StopTime:     timestamppb.New(r.StopTime),
+		Reason:       string(r.Reason),
+		TotalRunTime: durationpb.New(r.TotalRunTime),
+		CpuTime:      durationpb.New(r.CPUTime),
+		MaxMemory:    r.MaxMemory,
+		Energy:       r.Energy,
+	}
+	if r.ExitCode != nil {
+		code := int32(*r.ExitCode)
+		p.ExitCode = &code
+	}
+	return p
+}
+
+func jobRecordFromProto(p *workerpb.JobRecord) JobRecord {
+	r := JobRecord{
+		ID:           p.GetId(),
+		JobID:        p.GetJobId(),
+		Spec:         jobFromProto(p.GetSpec()),
+		StartTime:    p.GetStartTime().AsTime(),
+		StopTime:     p.GetStopTime().AsTime(),
+		Reason:       TerminationReason(p.GetReason()),
+		TotalRunTime: p.GetTotalRunTime().AsDuration(),
+		CPUTime:      p.GetCpuTime().AsDuration(),
+		MaxMemory:    p.GetMaxMemory(),
+		Energy:       p.GetEnergy(),
+	}
+	if p.ExitCode != nil {
+		code := int(p.GetExitCode())
+		r.ExitCode = &code
+	}
+	return r
+}
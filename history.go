@@ -0,0 +1,258 @@
+package worker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	job "github.com/Nguyen-Hoa/job"
+)
+
+var errNoHistory = errors.New("worker keeps no job history")
+
+// maxRecentRecords is how many records of finished jobs are kept in memory
+// for JobStatus.
+const maxRecentRecords = 1000
+
+// TerminationReason is why a job stopped running.
+type TerminationReason string
+
+const (
+	// ReasonCompleted jobs exited on their own
+	ReasonCompleted TerminationReason = "completed"
+	// ReasonDurationExceeded jobs were killed at the end of their duration
+	ReasonDurationExceeded TerminationReason = "duration-exceeded"
+	// ReasonStopped jobs were stopped with StopJob
+	ReasonStopped TerminationReason = "stopped"
+	// ReasonOrphan containers were killed by the orphan policy
+	ReasonOrphan TerminationReason = "orphan"
+	// ReasonPowerCapped jobs were stopped to cap the worker's power
+	ReasonPowerCapped TerminationReason = "power-capped"
+)
+
+// JobRecord is the history of a job that ran on the worker.
+type JobRecord struct {
+	// ID is the container ID, as returned by StartJob
+	ID        string    `json:"id"`
+	JobID     string    `json:"jobId,omitempty"`
+	Spec      JobSpec   `json:"spec"`
+	StartTime time.Time `json:"startTime"`
+	StopTime  time.Time `json:"stopTime"`
+	// ExitCode is nil when the job's exit status is not known
+	ExitCode     *int              `json:"exitCode,omitempty"`
+	Reason       TerminationReason `json:"reason"`
+	TotalRunTime time.Duration     `json:"totalRunTime"`
+	CPUTime      time.Duration     `json:"cpuTime"`
+	// MaxMemory is the peak memory seen, in bytes
+	MaxMemory uint64 `json:"maxMemory"`
+	// Energy is in joules
+	Energy float64 `json:"energy"`
+}
+
+// GobEncode and GobDecode keep an exit code of 0 over net/rpc, like
+// JobStatus.
+func (r JobRecord) GobEncode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *JobRecord) GobDecode(buf []byte) error {
+	return json.Unmarshal(buf, r)
+}
+
+// HistoryQuery selects the records returned by ListJobHistory. Zero fields
+// select everything.
+type HistoryQuery struct {
+	// Since and Until bound the stop time of the jobs
+	Since  time.Time         `json:"since,omitempty"`
+	Until  time.Time         `json:"until,omitempty"`
+	Reason TerminationReason `json:"reason,omitempty"`
+	// Limit keeps the latest records only
+	Limit int `json:"limit,omitempty"`
+}
+
+func (q HistoryQuery) includes(r JobRecord) bool {
+	return (q.Since.IsZero() || !r.StopTime.Before(q.Since)) &&
+		(q.Until.IsZero() || !r.StopTime.After(q.Until)) &&
+		(q.Reason == "" || r.Reason == q.Reason)
+}
+
+// jobHistory tracks the jobs running on the worker and appends a JobRecord
+// to a JSON lines file for each one that stops.
+type jobHistory struct {
+	path    string
+	mu      sync.Mutex
+	running map[string]JobRecord
+	recent  map[string]JobRecord
+	order   []string
+}
+
+func (h *jobHistory) init(path string) {
+	h.path = path
+	h.running = make(map[string]JobRecord)
+	h.recent = make(map[string]JobRecord)
+}
+
+// started begins the record of a job.
+func (h *jobHistory) started(r JobRecord) {
+	h.mu.Lock()
+	h.running[r.ID] = r
+	h.mu.Unlock()
+}
+
+// startedContainer begins the record of a job the worker did not start
+// itself in this run, from what its container tells.
+func (h *jobHistory) startedContainer(j job.DockerJob) {
+	h.started(JobRecord{
+		ID:    j.ID,
+		JobID: j.Labels[LabelJobID],
+		Spec: JobSpec{
+			Job:    job.Job{Image: j.Image, Cmd: strings.Fields(j.Command), Duration: int(j.BaseJob.Duration / time.Second)},
+			Labels: j.Labels,
+		},
+		StartTime: j.StartTime,
+	})
+}
+
+// tracked returns the IDs of the jobs with a record begun.
+func (h *jobHistory) tracked() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ids := make([]string, 0, len(h.running))
+	for id := range h.running {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// stopping sets why a job is being stopped, ahead of its exit. An empty
+// reason clears it.
+func (h *jobHistory) stopping(ID string, reason TerminationReason) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if r, ok := h.running[ID]; ok {
+		r.Reason = reason
+		h.running[ID] = r
+	}
+}
+
+// finish completes the record of a job and appends it to the history file.
+// reason only applies when the job is not being stopped. It reports false,
+// doing nothing, when the job has no record begun, e.g. it was already
+// finished.
+func (h *jobHistory) finish(ID string, reason TerminationReason, exitCode *int, usage jobUsage) (JobRecord, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	r, ok := h.running[ID]
+	if !ok {
+		return JobRecord{}, false
+	}
+	delete(h.running, ID)
+
+	r.StopTime = time.Now()
+	if r.Reason == "" {
+		r.Reason = reason
+	}
+	r.ExitCode = exitCode
+	if !r.StartTime.IsZero() {
+		r.TotalRunTime = r.StopTime.Sub(r.StartTime)
+	}
+	r.CPUTime = usage.CPUTime
+	r.MaxMemory = usage.MaxMemory
+	r.Energy = usage.Energy
+
+	if h.path != "" {
+		if err := h.append(r); err != nil {
+			log.Print(err)
+		}
+	}
+	h.recent[ID] = r
+	h.order = append(h.order, ID)
+	if len(h.order) > maxRecentRecords {
+		delete(h.recent, h.order[0])
+		h.order = h.order[1:]
+	}
+	return r, true
+}
+
+// find returns the record of a finished job, looking through the history
+// file for jobs no longer kept in memory.
+func (h *jobHistory) find(ID string) (JobRecord, bool) {
+	h.mu.Lock()
+	r, ok := h.recent[ID]
+	h.mu.Unlock()
+	if ok || h.path == "" {
+		return r, ok
+	}
+	records, err := h.list(HistoryQuery{})
+	if err != nil {
+		log.Print(err)
+		return JobRecord{}, false
+	}
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].ID == ID {
+			return records[i], true
+		}
+	}
+	return JobRecord{}, false
+}
+
+func (h *jobHistory) append(r JobRecord) error {
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(f).Encode(r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// list reads the records selected by q from the history file, oldest first.
+func (h *jobHistory) list(q HistoryQuery) ([]JobRecord, error) {
+	if h.path == "" {
+		return nil, errNoHistory
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	f, err := os.Open(h.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []JobRecord{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	records := make([]JobRecord, 0)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var r JobRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			log.Print(err)
+			continue
+		}
+		if q.includes(r) {
+			records = append(records, r)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if q.Limit > 0 && len(records) > q.Limit {
+		records = records[len(records)-q.Limit:]
+	}
+	return records, nil
+}
+
+// ListJobHistory returns the records of the jobs that stopped running on the
+// worker, oldest first.
+func (w *ServerWorker) ListJobHistory(ctx context.Context, q HistoryQuery) ([]JobRecord, error) {
+	return w.history.list(q)
+}
@@ -0,0 +1,99 @@
+package worker
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHistoryQueryIncludes(t *testing.T) {
+	t0 := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	r := JobRecord{ID: "a", StopTime: t0, Reason: ReasonStopped}
+	tests := []struct {
+		name string
+		q    HistoryQuery
+		want bool
+	}{
+		{"everything", HistoryQuery{}, true},
+		{"since before", HistoryQuery{Since: t0.Add(-time.Second)}, true},
+		{"since at", HistoryQuery{Since: t0}, true},
+		{"since after", HistoryQuery{Since: t0.Add(time.Second)}, false},
+		{"until after", HistoryQuery{Until: t0.Add(time.Second)}, true},
+		{"until at", HistoryQuery{Until: t0}, true},
+		{"until before", HistoryQuery{Until: t0.Add(-time.Second)}, false},
+		{"same reason", HistoryQuery{Reason: ReasonStopped}, true},
+		{"other reason", HistoryQuery{Reason: ReasonCompleted}, false},
+		{"all match", HistoryQuery{Since: t0, Until: t0, Reason: ReasonStopped}, true},
+		{"one fails", HistoryQuery{Since: t0, Until: t0, Reason: ReasonOrphan}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.q.includes(r); got != tt.want {
+				t.Errorf("includes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJobHistory(t *testing.T) {
+	var h jobHistory
+	h.init(filepath.Join(t.TempDir(), "history.jsonl"))
+	for _, id := range []string{"a", "b", "c"} {
+		h.started(JobRecord{ID: id, StartTime: time.Now()})
+	}
+	h.stopping("b", ReasonDurationExceeded)
+	h.finish("a", ReasonCompleted, intPtr(0), jobUsage{})
+	h.finish("b", ReasonCompleted, intPtr(137), jobUsage{Energy: 3})
+	if _, ok := h.finish("b", ReasonCompleted, intPtr(0), jobUsage{}); ok {
+		t.Error("finish() of a finished job = true, want false")
+	}
+	if got := h.tracked(); len(got) != 1 || got[0] != "c" {
+		t.Errorf("tracked() = %v, want [c]", got)
+	}
+
+	r, ok := h.find("b")
+	if !ok || r.Reason != ReasonDurationExceeded || *r.ExitCode != 137 || r.Energy != 3 {
+		t.Errorf("find() = %+v, %v, want b stopped for its duration", r, ok)
+	}
+	if _, ok := h.find("c"); ok {
+		t.Error("find() of a running job = true, want false")
+	}
+
+	tests := []struct {
+		name string
+		q    HistoryQuery
+		want []string
+	}{
+		{"all", HistoryQuery{}, []string{"a", "b"}},
+		{"limit", HistoryQuery{Limit: 1}, []string{"b"}},
+		{"limit over count", HistoryQuery{Limit: 5}, []string{"a", "b"}},
+		{"reason", HistoryQuery{Reason: ReasonCompleted}, []string{"a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := h.list(tt.q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, r := range records {
+				got = append(got, r.ID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("list() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("list() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	// records no longer in memory are found in the file
+	var reopened jobHistory
+	reopened.init(h.path)
+	if r, ok := reopened.find("a"); !ok || r.ExitCode == nil || *r.ExitCode != 0 {
+		t.Errorf("find() after reopening = %+v, %v, want a with exit code 0", r, ok)
+	}
+}
@@ -0,0 +1,342 @@
+package worker
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	http "net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Handler returns an http.Handler serving the routes ManagerWorker calls
+// when talking to an HTTP worker. Every response body is JSON; failures are
+// reported as {"error": "..."} with a non-200 status code.
+func (w *ServerWorker) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/execute", w.handleExecute)
+	mux.HandleFunc("/stop", w.handleStop)
+	mux.HandleFunc("/stats", w.handleStats)
+	mux.HandleFunc("/reduced-stats", w.handleReducedStats)
+	mux.HandleFunc("/running_jobs", w.handleRunningJobs)
+	mux.HandleFunc("/running_jobs_stats", w.handleRunningJobsStats)
+	mux.HandleFunc("/available", w.handleAvailable)
+	mux.HandleFunc("/drain", w.handleDrain)
+	mux.HandleFunc("/undrain", w.handleUndrain)
+	mux.HandleFunc("/has-power-meter", w.handleHasPowerMeter)
+	mux.HandleFunc("/power-model", w.handlePowerModel)
+	mux.HandleFunc("/power-cap-actions", w.handlePowerCapActions)
+	mux.HandleFunc("/history", w.handleHistory)
+	mux.HandleFunc("/job-status", w.handleJobStatus)
+	mux.HandleFunc("/meter-start", w.handleMeterStart)
+	mux.HandleFunc("/meter-stop", w.handleMeterStop)
+	mux.HandleFunc("/meter-log", w.handleMeterLog)
+	return mux
+}
+
+func writeJSON(rw http.ResponseWriter, status int, body interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	if err := json.NewEncoder(rw).Encode(body); err != nil {
+		log.Print(err)
+	}
+}
+
+func writeError(rw http.ResponseWriter, status int, err error) {
+	writeJSON(rw, status, map[string]string{"error": err.Error()})
+}
+
+// statusFor picks the status code reporting err.
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidJobSpec), errors.Is(err, ErrResourceLimit):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrWorkerSaturated), errors.Is(err, ErrWorkerDraining):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, ErrJobNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func allowMethod(rw http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method != method {
+		rw.Header().Set("Allow", method)
+		writeError(rw, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return false
+	}
+	return true
+}
+
+func (w *ServerWorker) handleExecute(rw http.ResponseWriter, r *http.Request) {
+	if !allowMethod(rw, r, http.MethodPost) {
+		return
+	}
+	var j JobSpec
+	if err := json.NewDecoder(r.Body).Decode(&j); err != nil {
+		writeError(rw, http.StatusBadRequest, err)
+		return
+	}
+	id, err := w.StartJob(r.Context(), j)
+	if err != nil {
+		writeError(rw, statusFor(err), err)
+		return
+	}
+	writeJSON(rw, http.StatusOK, map[string]string{"id": id})
+}
+
+func (w *ServerWorker) handleStop(rw http.ResponseWriter, r *http.Request) {
+	if !allowMethod(rw, r, http.MethodPost) {
+		return
+	}
+	var body struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(rw, http.StatusBadRequest, err)
+		return
+	}
+	res, err := w.StopJob(r.Context(), body.ID)
+	if err != nil {
+		writeError(rw, statusFor(err), err)
+		return
+	}
+	writeJSON(rw, http.StatusOK, res)
+}
+
+func (w *ServerWorker) handleStats(rw http.ResponseWriter, r *http.Request) {
+	if !allowMethod(rw, r, http.MethodGet) {
+		return
+	}
+	stats, err := w.Stats(r.Context())
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(rw, http.StatusOK, stats)
+}
+
+func (w *ServerWorker) handleReducedStats(rw http.ResponseWriter, r *http.Request) {
+	if !allowMethod(rw, r, http.MethodGet) {
+		return
+	}
+	stats, err := w.ReducedStats(r.Context())
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(rw, http.StatusOK, stats)
+}
+
+func (w *ServerWorker) handleRunningJobs(rw http.ResponseWriter, r *http.Request) {
+	if !allowMethod(rw, r, http.MethodGet) {
+		return
+	}
+	jobs, err := w.GetRunningJobs(r.Context())
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(rw, http.StatusOK, jobs)
+}
+
+// handleRunningJobsStats encodes the raw docker stats of each container as
+// a []byte, mirroring the reply of RPCServerWorker.GetRunningJobsStats.
+func (w *ServerWorker) handleRunningJobsStats(rw http.ResponseWriter, r *http.Request) {
+	if !allowMethod(rw, r, http.MethodGet) {
+		return
+	}
+	stats, err := w.GetRunningJobsStats(r.Context())
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(rw, http.StatusOK, stats)
+}
+
+// availability is the body of /available.
+type availability struct {
+	Available bool  `json:"available"`
+	State     State `json:"state"`
+}
+
+func (w *ServerWorker) handleAvailable(rw http.ResponseWriter, r *http.Request) {
+	if !allowMethod(rw, r, http.MethodGet) {
+		return
+	}
+	state, err := w.GetState(r.Context())
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(rw, http.StatusOK, availability{Available: state.Accepting(), State: state})
+}
+
+func (w *ServerWorker) handleDrain(rw http.ResponseWriter, r *http.Request) {
+	if !allowMethod(rw, r, http.MethodPost) {
+		return
+	}
+	state := w.setDraining(r.Context(), true)
+	writeJSON(rw, http.StatusOK, availability{Available: state.Accepting(), State: state})
+}
+
+func (w *ServerWorker) handleUndrain(rw http.ResponseWriter, r *http.Request) {
+	if !allowMethod(rw, r, http.MethodPost) {
+		return
+	}
+	state := w.setDraining(r.Context(), false)
+	writeJSON(rw, http.StatusOK, availability{Available: state.Accepting(), State: state})
+}
+
+func (w *ServerWorker) handleHasPowerMeter(rw http.ResponseWriter, r *http.Request) {
+	if !allowMethod(rw, r, http.MethodGet) {
+		return
+	}
+	writeJSON(rw, http.StatusOK, map[string]bool{"hasPowerMeter": w.PowerMeterOn()})
+}
+
+func (w *ServerWorker) handlePowerModel(rw http.ResponseWriter, r *http.Request) {
+	if !allowMethod(rw, r, http.MethodGet) {
+		return
+	}
+	m, err := w.GetPowerModel(r.Context())
+	if errors.Is(err, errNoPowerModel) {
+		writeError(rw, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		writeError(rw, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(rw, http.StatusOK, m)
+}
+
+func (w *ServerWorker) handlePowerCapActions(rw http.ResponseWriter, r *http.Request) {
+	if !allowMethod(rw, r, http.MethodGet) {
+		return
+	}
+	actions, err := w.PowerCapActions(r.Context())
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(rw, http.StatusOK, actions)
+}
+
+func (w *ServerWorker) handleMeterStart(rw http.ResponseWriter, r *http.Request) {
+	if !allowMethod(rw, r, http.MethodPost) {
+		return
+	}
+	if !w.HasPowerMeter {
+		writeError(rw, http.StatusConflict, errNoPowerMeter)
+		return
+	}
+	if err := w.StartMeter(r.Context()); err != nil {
+		writeError(rw, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(rw, http.StatusOK, map[string]string{"path": w.GetMeterPath()})
+}
+
+func (w *ServerWorker) handleMeterStop(rw http.ResponseWriter, r *http.Request) {
+	if !allowMethod(rw, r, http.MethodPost) {
+		return
+	}
+	if !w.HasPowerMeter {
+		writeError(rw, http.StatusConflict, errNoPowerMeter)
+		return
+	}
+	path, err := w.StopMeter(r.Context())
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(rw, http.StatusOK, map[string]string{"path": path})
+}
+
+// handleMeterLog serves the readings of a meter log, selected by the path,
+// from and to (RFC 3339) query parameters.
+func (w *ServerWorker) handleMeterLog(rw http.ResponseWriter, r *http.Request) {
+	if !allowMethod(rw, r, http.MethodGet) {
+		return
+	}
+	if !w.HasPowerMeter {
+		writeError(rw, http.StatusConflict, errNoPowerMeter)
+		return
+	}
+	params := r.URL.Query()
+	q := MeterLogQuery{Path: params.Get("path")}
+	for name, t := range map[string]*time.Time{"from": &q.From, "to": &q.To} {
+		if v := params.Get(name); v != "" {
+			parsed, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				writeError(rw, http.StatusBadRequest, err)
+				return
+			}
+			*t = parsed
+		}
+	}
+	readings, err := w.MeterLog(r.Context(), q)
+	switch {
+	case errors.Is(err, errNotMeterLog):
+		writeError(rw, http.StatusBadRequest, err)
+	case errors.Is(err, os.ErrNotExist):
+		writeError(rw, http.StatusNotFound, err)
+	case err != nil:
+		writeError(rw, http.StatusInternalServerError, err)
+	default:
+		writeJSON(rw, http.StatusOK, readings)
+	}
+}
+
+// handleHistory serves the job history, selected by the since, until
+// (RFC 3339), reason and limit query parameters.
+func (w *ServerWorker) handleHistory(rw http.ResponseWriter, r *http.Request) {
+	if !allowMethod(rw, r, http.MethodGet) {
+		return
+	}
+	params := r.URL.Query()
+	q := HistoryQuery{Reason: TerminationReason(params.Get("reason"))}
+	for name, t := range map[string]*time.Time{"since": &q.Since, "until": &q.Until} {
+		if v := params.Get(name); v != "" {
+			parsed, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				writeError(rw, http.StatusBadRequest, err)
+				return
+			}
+			*t = parsed
+		}
+	}
+	if v := params.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(rw, http.StatusBadRequest, err)
+			return
+		}
+		q.Limit = limit
+	}
+	records, err := w.ListJobHistory(r.Context(), q)
+	switch {
+	case errors.Is(err, errNoHistory):
+		writeError(rw, http.StatusNotFound, err)
+	case err != nil:
+		writeError(rw, http.StatusInternalServerError, err)
+	default:
+		writeJSON(rw, http.StatusOK, records)
+	}
+}
+
+// handleJobStatus serves the status of the job selected by the id query
+// parameter.
+func (w *ServerWorker) handleJobStatus(rw http.ResponseWriter, r *http.Request) {
+	if !allowMethod(rw, r, http.MethodGet) {
+		return
+	}
+	status, err := w.JobStatus(r.Context(), r.URL.Query().Get("id"))
+	if err != nil {
+		writeError(rw, statusFor(err), err)
+		return
+	}
+	writeJSON(rw, http.StatusOK, status)
+}
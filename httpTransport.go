@@ -0,0 +1,222 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	http "net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	job "github.com/Nguyen-Hoa/job"
+)
+
+// HTTPTransport talks to a worker served by ServerWorker.Handler.
+type HTTPTransport struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPTransport returns a transport sending requests to baseURL, e.g.
+// "http://worker.address.com:8080", through client.
+func NewHTTPTransport(baseURL string, client *http.Client) *HTTPTransport {
+	return &HTTPTransport{baseURL: baseURL, client: client}
+}
+
+// do sends a request to the worker's HTTP handler and decodes the JSON
+// response into out, when out is not nil.
+func (t *HTTPTransport) do(ctx context.Context, method string, path string, in interface{}, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		buf, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(buf)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, t.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 {
+		return httpError(res)
+	}
+	if out != nil {
+		return json.NewDecoder(res.Body).Decode(out)
+	}
+	return nil
+}
+
+// httpError turns the {"error": "..."} body written by ServerWorker.Handler
+// into an error.
+func httpError(res *http.Response) error {
+	body := make(map[string]string)
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil || body["error"] == "" {
+		return errors.New(res.Status)
+	}
+	return remoteError(body["error"])
+}
+
+func (t *HTTPTransport) StartJob(ctx context.Context, j JobSpec) (string, error) {
+	body := make(map[string]string)
+	if err := t.do(ctx, http.MethodPost, "/execute", j, &body); err != nil {
+		return "", err
+	}
+	return body["id"], nil
+}
+
+func (t *HTTPTransport) StopJob(ctx context.Context, ID string) (JobResult, error) {
+	var res JobResult
+	if err := t.do(ctx, http.MethodPost, "/stop", map[string]string{"id": ID}, &res); err != nil {
+		return JobResult{}, err
+	}
+	return res, nil
+}
+
+func (t *HTTPTransport) GetRunningJobs(ctx context.Context) (map[string]job.DockerJob, error) {
+	var jobs map[string]job.DockerJob
+	if err := t.do(ctx, http.MethodGet, "/running_jobs", nil, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (t *HTTPTransport) GetRunningJobsStats(ctx context.Context) (map[string][]byte, error) {
+	var stats map[string][]byte
+	if err := t.do(ctx, http.MethodGet, "/running_jobs_stats", nil, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (t *HTTPTransport) Poll(ctx context.Context) (map[string]interface{}, error) {
+	var stats map[string]interface{}
+	if err := t.do(ctx, http.MethodGet, "/stats", nil, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (t *HTTPTransport) ReducedStats(ctx context.Context) (map[string]interface{}, error) {
+	var stats map[string]interface{}
+	if err := t.do(ctx, http.MethodGet, "/reduced-stats", nil, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (t *HTTPTransport) StartMeter(ctx context.Context) error {
+	return t.do(ctx, http.MethodPost, "/meter-start", nil, nil)
+}
+
+func (t *HTTPTransport) StopMeter(ctx context.Context) (string, error) {
+	body := make(map[string]string)
+	if err := t.do(ctx, http.MethodPost, "/meter-stop", nil, &body); err != nil {
+		return "", err
+	}
+	return body["path"], nil
+}
+
+func (t *HTTPTransport) GetState(ctx context.Context) (State, error) {
+	var body availability
+	if err := t.do(ctx, http.MethodGet, "/available", nil, &body); err != nil {
+		return "", err
+	}
+	return body.State, nil
+}
+
+func (t *HTTPTransport) Drain(ctx context.Context) error {
+	return t.do(ctx, http.MethodPost, "/drain", nil, nil)
+}
+
+func (t *HTTPTransport) Undrain(ctx context.Context) error {
+	return t.do(ctx, http.MethodPost, "/undrain", nil, nil)
+}
+
+func (t *HTTPTransport) GetPowerModel(ctx context.Context) (*RegressionPowerModel, error) {
+	body := &RegressionPowerModel{}
+	if err := t.do(ctx, http.MethodGet, "/power-model", nil, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+func (t *HTTPTransport) MeterLog(ctx context.Context, q MeterLogQuery) ([]PowerReading, error) {
+	params := url.Values{}
+	if q.Path != "" {
+		params.Set("path", q.Path)
+	}
+	if !q.From.IsZero() {
+		params.Set("from", q.From.Format(time.RFC3339Nano))
+	}
+	if !q.To.IsZero() {
+		params.Set("to", q.To.Format(time.RFC3339Nano))
+	}
+	var readings []PowerReading
+	if err := t.do(ctx, http.MethodGet, "/meter-log?"+params.Encode(), nil, &readings); err != nil {
+		return nil, err
+	}
+	return readings, nil
+}
+
+func (t *HTTPTransport) PowerCapActions(ctx context.Context) ([]PowerCapAction, error) {
+	var actions []PowerCapAction
+	if err := t.do(ctx, http.MethodGet, "/power-cap-actions", nil, &actions); err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
+
+func (t *HTTPTransport) ListJobHistory(ctx context.Context, q HistoryQuery) ([]JobRecord, error) {
+	params := url.Values{}
+	if !q.Since.IsZero() {
+		params.Set("since", q.Since.Format(time.RFC3339Nano))
+	}
+	if !q.Until.IsZero() {
+		params.Set("until", q.Until.Format(time.RFC3339Nano))
+	}
+	if q.Reason != "" {
+		params.Set("reason", string(q.Reason))
+	}
+	if q.Limit > 0 {
+		params.Set("limit", strconv.Itoa(q.Limit))
+	}
+	var records []JobRecord
+	if err := t.do(ctx, http.MethodGet, "/history?"+params.Encode(), nil, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (t *HTTPTransport) JobStatus(ctx context.Context, ID string) (JobStatus, error) {
+	var status JobStatus
+	params := url.Values{"id": {ID}}
+	if err := t.do(ctx, http.MethodGet, "/job-status?"+params.Encode(), nil, &status); err != nil {
+		return JobStatus{}, err
+	}
+	return status, nil
+}
+
+func (t *HTTPTransport) PowerMeterOn(ctx context.Context) (bool, error) {
+	body := make(map[string]bool)
+	if err := t.do(ctx, http.MethodGet, "/has-power-meter", nil, &body); err != nil {
+		return false, err
+	}
+	return body["hasPowerMeter"], nil
+}
+
+func (t *HTTPTransport) Close() error {
+	t.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,53 @@
+package worker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPTransport(t *testing.T) {
+	w := &ServerWorker{}
+	initTestWorker(t, w)
+	server := httptest.NewServer(w.Handler())
+	defer server.Close()
+
+	tr := NewHTTPTransport(server.URL, server.Client())
+	defer tr.Close()
+	testTransport(t, tr)
+}
+
+func TestHTTPStatusCodes(t *testing.T) {
+	w := &ServerWorker{}
+	initTestWorker(t, w)
+	server := httptest.NewServer(w.Handler())
+	defer server.Close()
+
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{http.MethodGet, "/job-status?id=running", http.StatusOK},
+		{http.MethodGet, "/job-status?id=missing", http.StatusNotFound},
+		{http.MethodGet, "/stop", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/history?limit=x", http.StatusBadRequest},
+		{http.MethodGet, "/meter-log?from=x", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, server.URL+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := server.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
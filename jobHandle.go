@@ -0,0 +1,115 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	job "github.com/Nguyen-Hoa/job"
+)
+
+// waitPollInterval is how often JobHandle.Wait asks the worker whether the
+// job is still running.
+const waitPollInterval = time.Second
+
+// JobHandle is returned by ManagerWorker.Submit and ties a submitted job to
+// the container the worker launched for it.
+type JobHandle struct {
+	ID          string
+	Job         JobSpec
+	SubmittedAt time.Time
+
+	w *ManagerWorker
+}
+
+// Submit starts j on the worker and returns a handle to it.
+func (w *ManagerWorker) Submit(ctx context.Context, j JobSpec) (*JobHandle, error) {
+	id, err := w.StartJob(ctx, j)
+	if err != nil {
+		return nil, err
+	}
+	h := &JobHandle{ID: id, Job: j, SubmittedAt: time.Now(), w: w}
+	w.handlesMu.Lock()
+	w.handles[id] = h
+	w.handlesMu.Unlock()
+	return h, nil
+}
+
+// Handle returns the handle of a job submitted through this manager, e.g.
+// to match the keys of RunningJobStats with submitted jobs.
+func (w *ManagerWorker) Handle(ID string) (*JobHandle, bool) {
+	w.handlesMu.Lock()
+	defer w.handlesMu.Unlock()
+	h, ok := w.handles[ID]
+	return h, ok
+}
+
+func (w *ManagerWorker) forget(ID string) {
+	w.handlesMu.Lock()
+	delete(w.handles, ID)
+	w.handlesMu.Unlock()
+}
+
+// pruneHandles forgets the jobs no longer running on the worker, e.g. those
+// killed at the end of their duration or exiting on their own.
+func (w *ManagerWorker) pruneHandles(running map[string]job.DockerJob) {
+	w.handlesMu.Lock()
+	defer w.handlesMu.Unlock()
+	for id := range w.handles {
+		if _, ok := running[id]; !ok {
+			delete(w.handles, id)
+		}
+	}
+}
+
+func (h *JobHandle) Stop(ctx context.Context) (JobResult, error) {
+	res, err := h.w.StopJob(ctx, h.ID)
+	if err != nil {
+		return JobResult{}, err
+	}
+	h.w.forget(h.ID)
+	return res, nil
+}
+
+// Status asks the worker whether the job is running or how it finished. A
+// job the worker no longer knows of is reported as JobFinished.
+func (h *JobHandle) Status(ctx context.Context) (JobStatus, error) {
+	status, err := h.w.JobStatus(ctx, h.ID)
+	if errors.Is(err, ErrJobNotFound) {
+		return JobStatus{ID: h.ID, State: JobFinished}, nil
+	} else if err != nil {
+		return JobStatus{}, err
+	}
+	return status, nil
+}
+
+// Wait blocks until the job is no longer running on the worker or ctx is
+// done.
+func (h *JobHandle) Wait(ctx context.Context) (JobStatus, error) {
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+	for {
+		status, err := h.Status(ctx)
+		if err != nil {
+			return JobStatus{}, err
+		}
+		if status.State != JobRunning {
+			h.w.forget(h.ID)
+			return status, nil
+		}
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stats returns the container stats last fetched for the job by
+// ManagerWorker.Stats or ContainerStats.
+func (h *JobHandle) Stats() (map[string]interface{}, bool) {
+	h.w.statsMu.Lock()
+	defer h.w.statsMu.Unlock()
+	stats, ok := h.w.RunningJobStats[h.ID].(map[string]interface{})
+	return stats, ok
+}
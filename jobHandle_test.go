@@ -0,0 +1,82 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	job "github.com/Nguyen-Hoa/job"
+	"github.com/docker/docker/api/types"
+)
+
+// jobsTransport reports a fixed set of running jobs. Other calls are not
+// implemented.
+type jobsTransport struct {
+	Transport
+	jobs map[string]job.DockerJob
+}
+
+func (t *jobsTransport) GetRunningJobs(ctx context.Context) (map[string]job.DockerJob, error) {
+	return t.jobs, nil
+}
+
+func (t *jobsTransport) GetRunningJobsStats(ctx context.Context) (map[string][]byte, error) {
+	stats := make(map[string][]byte, len(t.jobs))
+	for id := range t.jobs {
+		stats[id], _ = json.Marshal(map[string]interface{}{"id": id})
+	}
+	return stats, nil
+}
+
+func newTestManager(jobs ...string) *ManagerWorker {
+	t := &jobsTransport{jobs: make(map[string]job.DockerJob)}
+	for _, id := range jobs {
+		t.jobs[id] = job.DockerJob{Container: types.Container{ID: id}}
+	}
+	w := &ManagerWorker{transport: t, handles: make(map[string]*JobHandle)}
+	w.RunningJobs.Init()
+	w.RunningJobStats = make(map[string]interface{})
+	return w
+}
+
+func TestGetRunningJobsPrunesHandles(t *testing.T) {
+	w := newTestManager("running")
+	for _, id := range []string{"running", "finished"} {
+		w.handles[id] = &JobHandle{ID: id, w: w}
+	}
+	if _, err := w.GetRunningJobs(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := w.Handle("running"); !ok {
+		t.Error("Handle() of a running job = false, want true")
+	}
+	if _, ok := w.Handle("finished"); ok {
+		t.Error("Handle() of a job no longer running = true, want false")
+	}
+}
+
+// TestJobHandleStats reads stats while they are fetched. Run with -race.
+func TestJobHandleStats(t *testing.T) {
+	w := newTestManager("running")
+	h := &JobHandle{ID: "running", w: w}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			if _, err := w.ContainerStats(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		h.Stats()
+	}
+	wg.Wait()
+
+	if stats, ok := h.Stats(); !ok || stats["id"] != "running" {
+		t.Errorf("Stats() = %v, %v, want the job's stats", stats, ok)
+	}
+}
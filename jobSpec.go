@@ -0,0 +1,123 @@
+package worker
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	job "github.com/Nguyen-Hoa/job"
+	"github.com/docker/docker/api/types/container"
+)
+
+// JobSpec extends job.Job with the container settings passed to docker
+// when the job is started.
+type JobSpec struct {
+	job.Job
+	// Env entries are KEY=VALUE pairs
+	Env []string `json:"env,omitempty"`
+	// Volumes are bind mounts in docker's src:dst[:options] form
+	Volumes    []string          `json:"volumes,omitempty"`
+	WorkingDir string            `json:"workingDir,omitempty"`
+	User       string            `json:"user,omitempty"`
+	Network    string            `json:"network,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+
+	// CPUs limits the job to that many of the worker's cores, e.g. 1.5
+	CPUs float64 `json:"cpus,omitempty"`
+	// CpusetCpus pins the job to cores in docker's "0-3,5" form
+	CpusetCpus string `json:"cpusetCpus,omitempty"`
+	// Memory limits the job's memory, in bytes
+	Memory int64 `json:"memory,omitempty"`
+}
+
+var volumeOptions = map[string]bool{
+	"ro": true, "rw": true, "z": true, "Z": true, "nocopy": true,
+	"shared": true, "rshared": true, "slave": true, "rslave": true,
+	"private": true, "rprivate": true,
+}
+
+func invalidJobSpec(format string, a ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidJobSpec, fmt.Sprintf(format, a...))
+}
+
+// Validate checks the spec before anything is sent to docker. The returned
+// error wraps ErrInvalidJobSpec.
+func (s JobSpec) Validate() error {
+	if s.Image == "" {
+		return invalidJobSpec("image is required")
+	}
+	if s.Duration <= 0 {
+		return invalidJobSpec("duration must be positive, got %d", s.Duration)
+	}
+	for _, e := range s.Env {
+		if i := strings.Index(e, "="); i <= 0 {
+			return invalidJobSpec("env %q is not KEY=VALUE", e)
+		}
+	}
+	for _, v := range s.Volumes {
+		parts := strings.Split(v, ":")
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+			return invalidJobSpec("volume %q is not src:dst[:options]", v)
+		}
+		if !path.IsAbs(parts[1]) {
+			return invalidJobSpec("volume %q must mount to an absolute path", v)
+		}
+		if len(parts) == 3 {
+			for _, opt := range strings.Split(parts[2], ",") {
+				if !volumeOptions[opt] {
+					return invalidJobSpec("volume %q has unknown option %q", v, opt)
+				}
+			}
+		}
+	}
+	if s.WorkingDir != "" && !path.IsAbs(s.WorkingDir) {
+		return invalidJobSpec("working dir %q must be absolute", s.WorkingDir)
+	}
+	if strings.ContainsAny(s.User, " \t\n") {
+		return invalidJobSpec("user %q contains whitespace", s.User)
+	}
+	if strings.ContainsAny(s.Network, " \t\n") || s.Network == "container:" {
+		return invalidJobSpec("network %q is not a valid network mode", s.Network)
+	}
+	for k := range s.Labels {
+		if k == "" {
+			return invalidJobSpec("label keys must not be empty")
+		}
+	}
+	if s.CPUs < 0 {
+		return invalidJobSpec("cpus must not be negative, got %g", s.CPUs)
+	}
+	if s.CpusetCpus != "" {
+		if _, err := parseCpuset(s.CpusetCpus); err != nil {
+			return invalidJobSpec("%v", err)
+		}
+	}
+	if s.Memory < 0 {
+		return invalidJobSpec("memory must not be negative, got %d", s.Memory)
+	}
+	return nil
+}
+
+func (s JobSpec) containerConfig() *container.Config {
+	return &container.Config{
+		Image:      s.Image,
+		Cmd:        s.Cmd,
+		Env:        s.Env,
+		WorkingDir: s.WorkingDir,
+		User:       s.User,
+		Labels:     s.Labels,
+	}
+}
+
+func (s JobSpec) hostConfig() *container.HostConfig {
+	return &container.HostConfig{
+		AutoRemove:  true,
+		Binds:       s.Volumes,
+		NetworkMode: container.NetworkMode(s.Network),
+		Resources: container.Resources{
+			NanoCPUs:   int64(s.CPUs * 1e9),
+			CpusetCpus: s.CpusetCpus,
+			Memory:     s.Memory,
+		},
+	}
+}
@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"errors"
+	"testing"
+
+	job "github.com/Nguyen-Hoa/job"
+)
+
+func TestJobSpecValidate(t *testing.T) {
+	valid := JobSpec{Job: job.Job{Image: "alpine", Duration: 10}}
+	with := func(f func(*JobSpec)) JobSpec {
+		s := valid
+		f(&s)
+		return s
+	}
+	tests := []struct {
+		name    string
+		spec    JobSpec
+		wantErr bool
+	}{
+		{"minimal", valid, false},
+		{"full", with(func(s *JobSpec) {
+			s.Env = []string{"A=1", "B="}
+			s.Volumes = []string{"/data:/data", "/src:/src:ro,z"}
+			s.WorkingDir = "/data"
+			s.User = "1000:1000"
+			s.Network = "host"
+			s.Labels = map[string]string{"team": "a"}
+			s.CPUs = 1.5
+			s.CpusetCpus = "0-1,3"
+			s.Memory = 1 << 30
+		}), false},
+		{"no image", with(func(s *JobSpec) { s.Image = "" }), true},
+		{"no duration", with(func(s *JobSpec) { s.Duration = 0 }), true},
+		{"negative duration", with(func(s *JobSpec) { s.Duration = -1 }), true},
+		{"env without value", with(func(s *JobSpec) { s.Env = []string{"A"} }), true},
+		{"env without key", with(func(s *JobSpec) { s.Env = []string{"=1"} }), true},
+		{"volume without target", with(func(s *JobSpec) { s.Volumes = []string{"/data"} }), true},
+		{"volume without source", with(func(s *JobSpec) { s.Volumes = []string{":/data"} }), true},
+		{"volume to relative path", with(func(s *JobSpec) { s.Volumes = []string{"/data:data"} }), true},
+		{"volume with unknown option", with(func(s *JobSpec) { s.Volumes = []string{"/data:/data:rx"} }), true},
+		{"relative working dir", with(func(s *JobSpec) { s.WorkingDir = "data" }), true},
+		{"user with space", with(func(s *JobSpec) { s.User = "a b" }), true},
+		{"network with space", with(func(s *JobSpec) { s.Network = "my net" }), true},
+		{"network of no container", with(func(s *JobSpec) { s.Network = "container:" }), true},
+		{"empty label key", with(func(s *JobSpec) { s.Labels = map[string]string{"": "a"} }), true},
+		{"negative cpus", with(func(s *JobSpec) { s.CPUs = -1 }), true},
+		{"invalid cpuset", with(func(s *JobSpec) { s.CpusetCpus = "3-1" }), true},
+		{"negative memory", with(func(s *JobSpec) { s.Memory = -1 }), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidJobSpec) {
+				t.Errorf("Validate() error = %v, want it to wrap %v", err, ErrInvalidJobSpec)
+			}
+		})
+	}
+}
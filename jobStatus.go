@@ -0,0 +1,171 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// exitWaitTimeout is how long stopJob waits for the exit status of a job it
+// stopped before recording it without one.
+const exitWaitTimeout = 5 * time.Second
+
+type JobState string
+
+const (
+	JobRunning JobState = "running"
+	// JobSucceeded jobs exited on their own with code 0
+	JobSucceeded JobState = "succeeded"
+	// JobFailed jobs exited on their own with a non-zero code
+	JobFailed JobState = "failed"
+	// JobKilled jobs were stopped by the worker, see Reason
+	JobKilled JobState = "killed"
+	// JobFinished jobs exited on their own with an unknown code
+	JobFinished JobState = "finished"
+)
+
+type JobStatus struct {
+	ID    string   `json:"id"`
+	State JobState `json:"state"`
+	// ExitCode is nil while running or when not known
+	ExitCode *int              `json:"exitCode,omitempty"`
+	Reason   TerminationReason `json:"reason,omitempty"`
+}
+
+// GobEncode and GobDecode keep an exit code of 0 over net/rpc, which gob
+// would send as no exit code.
+func (s JobStatus) GobEncode() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+func (s *JobStatus) GobDecode(buf []byte) error {
+	return json.Unmarshal(buf, s)
+}
+
+// statusOf tells the status of a finished job from its record.
+func statusOf(r JobRecord) JobStatus {
+	s := JobStatus{ID: r.ID, ExitCode: r.ExitCode, Reason: r.Reason}
+	switch {
+	case r.Reason != ReasonCompleted:
+		s.State = JobKilled
+	case r.ExitCode == nil:
+		s.State = JobFinished
+	case *r.ExitCode == 0:
+		s.State = JobSucceeded
+	default:
+		s.State = JobFailed
+	}
+	return s
+}
+
+// exitWatchers tracks the jobs waited on for their exit status. Containers
+// are removed by docker as soon as they exit, so the wait has to be in place
+// beforehand.
+type exitWatchers struct {
+	mu      sync.Mutex
+	waiting map[string]chan struct{}
+}
+
+func (e *exitWatchers) add(ID string) chan struct{} {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.waiting == nil {
+		e.waiting = make(map[string]chan struct{})
+	}
+	done := make(chan struct{})
+	e.waiting[ID] = done
+	return done
+}
+
+func (e *exitWatchers) done(ID string, done chan struct{}) {
+	e.mu.Lock()
+	if e.waiting[ID] == done {
+		delete(e.waiting, ID)
+	}
+	e.mu.Unlock()
+	close(done)
+}
+
+// get returns the channel closed once the exit of a job is recorded.
+func (e *exitWatchers) get(ID string) (chan struct{}, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	done, ok := e.waiting[ID]
+	return done, ok
+}
+
+// waitExit asks docker for the next exit status of a container. Cancel
+// releases the wait when the container is not started after all.
+func (w *ServerWorker) waitExit(ID string) (<-chan container.ContainerWaitOKBody, <-chan error, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	resultC, errC := w._docker.ContainerWait(ctx, ID, container.WaitConditionNextExit)
+	return resultC, errC, cancel
+}
+
+// watchJob records the exit status of a job once docker reports it.
+func (w *ServerWorker) watchJob(ID string, resultC <-chan container.ContainerWaitOKBody, errC <-chan error, cancel context.CancelFunc) {
+	done := w.exits.add(ID)
+	go func() {
+		defer cancel()
+		defer w.exits.done(ID, done)
+		select {
+		case res := <-resultC:
+			if res.Error != nil {
+				log.Printf("waiting on %s: %s", ID, res.Error.Message)
+			}
+			w.jobExited(ID, int(res.StatusCode))
+		case err := <-errC:
+			// the reconciler records the job without exit code
+			log.Print(err)
+		}
+	}()
+}
+
+// watchRunningJob is watchJob for a job already running.
+func (w *ServerWorker) watchRunningJob(ID string) {
+	resultC, errC, cancel := w.waitExit(ID)
+	w.watchJob(ID, resultC, errC, cancel)
+}
+
+// jobExited records a job as finished with exitCode, as completed unless
+// the worker was stopping it.
+func (w *ServerWorker) jobExited(ID string, exitCode int) {
+	r, ok := w.history.finish(ID, ReasonCompleted, &exitCode, w.energy.remove(ID))
+	if !ok {
+		return
+	}
+	w.deleteJob(&w.RunningJobs, ID)
+	log.Printf("Finished %s (%s, exit code %d), total run time: %s, energy: %.1f J", ID, r.Reason, exitCode, r.TotalRunTime, r.Energy)
+}
+
+// waitExited waits for the exit of a stopped job to be recorded, up to
+// exitWaitTimeout.
+func (w *ServerWorker) waitExited(ID string) {
+	done, ok := w.exits.get(ID)
+	if !ok {
+		return
+	}
+	select {
+	case <-done:
+	case <-time.After(exitWaitTimeout):
+		log.Printf("no exit status for %s after %s", ID, exitWaitTimeout)
+	}
+}
+
+// JobStatus returns whether a job is running, or how it finished. Finished
+// jobs are known until maxRecentRecords more jobs finish, or for as long as
+// the history file keeps them. The file is only read for jobs not running.
+func (w *ServerWorker) JobStatus(ctx context.Context, ID string) (JobStatus, error) {
+	if w.verifyContainer(ID) {
+		return JobStatus{ID: ID, State: JobRunning}, nil
+	}
+	if r, ok := w.history.find(ID); ok {
+		return statusOf(r), nil
+	}
+	return JobStatus{}, fmt.Errorf("%w: %s", ErrJobNotFound, ID)
+}
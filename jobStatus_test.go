@@ -0,0 +1,25 @@
+package worker
+
+import "testing"
+
+func TestStatusOf(t *testing.T) {
+	tests := []struct {
+		name string
+		r    JobRecord
+		want JobState
+	}{
+		{"exit 0", JobRecord{Reason: ReasonCompleted, ExitCode: intPtr(0)}, JobSucceeded},
+		{"exit 1", JobRecord{Reason: ReasonCompleted, ExitCode: intPtr(1)}, JobFailed},
+		{"no exit code", JobRecord{Reason: ReasonCompleted}, JobFinished},
+		{"stopped", JobRecord{Reason: ReasonStopped, ExitCode: intPtr(0)}, JobKilled},
+		{"duration exceeded", JobRecord{Reason: ReasonDurationExceeded, ExitCode: intPtr(137)}, JobKilled},
+		{"power capped", JobRecord{Reason: ReasonPowerCapped}, JobKilled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statusOf(tt.r).State; got != tt.want {
+				t.Errorf("statusOf() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
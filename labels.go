@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// Labels the worker stamps on the containers it starts.
+const (
+	labelPrefix = "io.github.nguyen-hoa.worker."
+	// LabelWorker is the name of the worker that started the container
+	LabelWorker = labelPrefix + "name"
+	// LabelJobID is an ID given to the job before its container exists
+	LabelJobID = labelPrefix + "job-id"
+	// LabelSubmitTime is when StartJob was called, in RFC 3339
+	LabelSubmitTime = labelPrefix + "submit-time"
+	// LabelDuration is the requested duration of the job, in seconds
+	LabelDuration = labelPrefix + "duration"
+	// LabelCPUs is the number of cores the job reserved
+	LabelCPUs = labelPrefix + "cpus"
+	// LabelMemory is the memory the job reserved, in bytes
+	LabelMemory = labelPrefix + "memory"
+)
+
+func newJobID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// ownerLabels are the labels marking a job as started by this worker, along
+// with what it reserved.
+func (w *ServerWorker) ownerLabels(j JobSpec, jobID string, submitted time.Time, res reservation) map[string]string {
+	labels := res.labels()
+	labels[LabelWorker] = w.Name
+	labels[LabelJobID] = jobID
+	labels[LabelSubmitTime] = submitted.UTC().Format(time.RFC3339Nano)
+	labels[LabelDuration] = strconv.Itoa(j.Duration)
+	return labels
+}
@@ -1,20 +1,27 @@
 package worker
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
-	"io"
 	"log"
-	http "net/http"
-	"net/rpc"
-	"strings"
 	"sync"
 
 	job "github.com/Nguyen-Hoa/job"
 )
 
 func New(config WorkerConfig) (*ManagerWorker, error) {
+	transport, err := newTransport(config)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	return NewWithTransport(config, transport)
+}
+
+// NewWithTransport is New with the protocol settings of config replaced
+// by an already connected transport.
+func NewWithTransport(config WorkerConfig, transport Transport) (*ManagerWorker, error) {
 	w := ManagerWorker{}
 	// Intialize Variables
 	w.Name = config.Name
@@ -28,27 +35,18 @@ func New(config WorkerConfig) (*ManagerWorker, error) {
 	w.RPCServer = config.RPCServer
 	w.RPCPort = config.RPCPort
 	w.HTTPPort = config.HTTPPort
-	if config.RPCServer && config.RPCPort != "" {
-		client, err := rpc.DialHTTP("tcp", config.Address+config.RPCPort)
-		if err != nil {
-			log.Print(err)
-			return nil, err
-		}
-		w.rpcClient = client
-	} else if config.HTTPPort != "" {
-		w.Address = "http://" + w.Address + w.HTTPPort
-	} else {
-		return nil, errors.New("no valid rpc or http configuration provided")
-	}
+	w.GRPCServer = config.GRPCServer
+	w.GRPCPort = config.GRPCPort
+	w.transport = transport
 
-	w.Available = w.IsAvailable()
-	if !w.Available {
+	ctx := context.Background()
+	if state, err := w.GetState(ctx); err != nil || state == StateUnavailable {
 		return nil, errors.New("worker not available, check that worker is running")
 	}
 
 	w.HasPowerMeter = w.PowerMeterOn()
 	if w.HasPowerMeter {
-		if err := w.StartMeter(); err != nil {
+		if err := w.StartMeter(ctx); err != nil {
 			log.Println("Worker meter failure", w.Name)
 			return nil, err
 		}
@@ -61,240 +59,208 @@ func New(config WorkerConfig) (*ManagerWorker, error) {
 	w.RunningJobStats = make(map[string]interface{})
 	w.RunningJobs = job.SharedDockerJobsMap{}
 	w.RunningJobs.Init()
+	w.handles = make(map[string]*JobHandle)
 
 	return &w, nil
 }
 
-func (w *ManagerWorker) StartMeter() error {
-	if w.RPCServer {
-		var reply string
-		if err := w.rpcClient.Call("RPCServerWorker.StartMeter", "", &reply); err != nil {
-			return err
-		}
-	} else {
-		if res, err := http.Post(w.Address+"/meter-start", "application/json", bytes.NewBufferString("")); res.StatusCode != 200 {
-			return err
-		}
-	}
-	return nil
+func (w *ManagerWorker) StartMeter(ctx context.Context) error {
+	return w.transport.StartMeter(ctx)
 }
 
-func (w *ManagerWorker) StopMeter() (string, error) {
-	var reply string
-	if w.RPCServer {
-		if err := w.rpcClient.Call("RPCServerWorker.StopMeter", "", &reply); err != nil {
-			return "", err
-		}
-	} else {
-		if res, err := http.Post(w.Address+"/meter-stop", "application/json", bytes.NewBufferString("")); res.StatusCode != 200 {
-			return "", err
-		} else {
-			defer res.Body.Close()
-			buf := new(bytes.Buffer)
-			io.Copy(buf, res.Body)
-			body := make(map[string]interface{})
-			json.Unmarshal(buf.Bytes(), &body)
-			reply = body["path"].(string)
-		}
-	}
-	return reply, nil
+func (w *ManagerWorker) StopMeter(ctx context.Context) (string, error) {
+	return w.transport.StopMeter(ctx)
 }
 
-func (w *ManagerWorker) StartJob(image string, cmd []string, duration int) error {
-	j := job.Job{Image: image, Cmd: cmd, Duration: duration}
-	if w.RPCServer {
-		var reply string
-		if err := w.rpcClient.Call("RPCServerWorker.StartJob", j, &reply); err != nil {
-			log.Print(err, reply)
-			return err
-		}
-	} else {
-		j, err := json.Marshal(j)
-		if err != nil {
-			return err
-		}
-		body := bytes.NewBuffer(j)
-		if _, err := http.Post(w.Address+"/execute", "application/json", body); err != nil {
-			return err
-		}
+// StartJob starts j on the worker. A worker over its thresholds refuses the
+// job with an error matching ErrWorkerSaturated, which can be unpacked with
+// errors.As into a *SaturatedError.
+func (w *ManagerWorker) StartJob(ctx context.Context, j JobSpec) (string, error) {
+	id, err := w.transport.StartJob(ctx, j)
+	if err != nil {
+		log.Print(err)
+		return "", err
 	}
-	return nil
+	return id, nil
 }
 
-func (w *ManagerWorker) Stats(reduced bool) (map[string]interface{}, error) {
-	if w.RPCServer {
-		var pollWaitGroup sync.WaitGroup
-		var errs = make([]string, 0)
-
-		pollWaitGroup.Add(1)
-		go func() {
-			defer pollWaitGroup.Done()
-			var endpoint string = "RPCServerWorker.Poll"
-			if reduced {
-				endpoint = "RPCServerWorker.ReducedStats"
-			}
-			var reply map[string]interface{}
-			if err := w.rpcClient.Call(endpoint, "", &reply); err != nil {
-				log.Print(err)
-				errs = append(errs, err.Error())
-			}
-			w.stats = reply
-			w.LatestCPU = float32(reply["cpupercent"].(float64))
-			w.LatestMem = float32(reply["vmem"].(float64))
-		}()
-
-		pollWaitGroup.Add(1)
-		go func() {
-			defer pollWaitGroup.Done()
-			var reply map[string][]byte
-			if err := w.rpcClient.Call("RPCServerWorker.GetRunningJobsStats", "", &reply); err != nil {
-				log.Print(err)
-				errs = append(errs, err.Error())
-			} else {
-				for key := range reply {
-					var stat map[string]interface{}
-					json.Unmarshal(reply[key], &stat)
-					w.RunningJobStats[key] = stat
-				}
-			}
-		}()
-
-		pollWaitGroup.Wait()
+// StopJob preempts a job on the worker instead of waiting for it to reach
+// its duration.
+func (w *ManagerWorker) StopJob(ctx context.Context, ID string) (JobResult, error) {
+	res, err := w.transport.StopJob(ctx, ID)
+	if err != nil {
+		log.Print(err)
+		return JobResult{}, err
+	}
+	w.RunningJobs.Delete(ID)
+	w.forget(ID)
+	return res, nil
+}
 
-		if len(errs) > 0 {
-			return nil, errors.New(errs[0])
-		}
-		return w.stats, nil
-	} else {
-		var pollWaitGroup sync.WaitGroup
-		var errs = make([]string, 0)
+func (w *ManagerWorker) GetRunningJobs(ctx context.Context) (map[string]job.DockerJob, error) {
+	jobs, err := w.transport.GetRunningJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	w.RunningJobs.InitFromMap(jobs)
+	w.pruneHandles(jobs)
+	return jobs, nil
+}
 
-		pollWaitGroup.Add(1)
-		go func() {
-			defer pollWaitGroup.Done()
-			var endpoint string = "/stats"
-			if reduced {
-				endpoint = "/reduced-stats"
-			}
-			resp, err := http.Get(w.Address + endpoint)
-			if err != nil {
-				errs = append(errs, err.Error())
-			}
-			defer resp.Body.Close()
-			buf := new(bytes.Buffer)
-			stats := make(map[string]interface{})
-			io.Copy(buf, resp.Body)
-			json.Unmarshal(buf.Bytes(), &stats)
-			w.stats = stats
-			w.LatestCPU = float32(stats["cpupercent"].(float64))
-			w.LatestMem = float32(stats["vmem"].(float64))
-		}()
+func (w *ManagerWorker) Stats(ctx context.Context) (map[string]interface{}, error) {
+	return w.poll(ctx, false)
+}
 
-		pollWaitGroup.Add(1)
-		go func() {
-			defer pollWaitGroup.Done()
-			resp, err := http.Get(w.Address + "/running_jobs_stats")
-			if err != nil {
-				errs = append(errs, err.Error())
-			}
-			defer resp.Body.Close()
-			buf := new(bytes.Buffer)
-			io.Copy(buf, resp.Body)
-			stats := make(map[string]interface{})
-			json.Unmarshal(buf.Bytes(), &stats)
-			w.RunningJobStats = stats
-		}()
-		pollWaitGroup.Wait()
-		if len(errs) > 0 {
-			return nil, errors.New(errs[0])
-		}
-		return w.stats, nil
-	}
+func (w *ManagerWorker) ReducedStats(ctx context.Context) (map[string]interface{}, error) {
+	return w.poll(ctx, true)
 }
 
-func (w *ManagerWorker) ContainerStats() (map[string][]byte, error) {
-	if w.RPCServer {
-		var reply map[string][]byte
-		if err := w.rpcClient.Call("RPCServerWorker.GetRunningJobsStats", "", &reply); err != nil {
-			log.Print(err)
-			return nil, err
+// poll fetches the worker's machine stats and, concurrently, the stats of
+// its running containers.
+func (w *ManagerWorker) poll(ctx context.Context, reduced bool) (map[string]interface{}, error) {
+	var pollWaitGroup sync.WaitGroup
+	var errs = make([]string, 0)
+	var mu sync.Mutex
+
+	pollWaitGroup.Add(1)
+	go func() {
+		defer pollWaitGroup.Done()
+		var stats map[string]interface{}
+		var err error
+		if reduced {
+			stats, err = w.transport.ReducedStats(ctx)
 		} else {
-			for key := range reply {
-				var stat map[string]interface{}
-				json.Unmarshal(reply[key], &stat)
-			}
-			return reply, nil
+			stats, err = w.transport.Poll(ctx)
 		}
-	} else {
-		resp, err := http.Get(w.Address + "/running_jobs_stats")
 		if err != nil {
 			log.Print(err)
-			return nil, err
+			mu.Lock()
+			errs = append(errs, err.Error())
+			mu.Unlock()
+			return
+		}
+		w.stats = stats
+		if cpu, ok := stats["cpupercent"].(float64); ok {
+			w.LatestCPU = float32(cpu)
 		}
-		defer resp.Body.Close()
-		buf := new(bytes.Buffer)
-		io.Copy(buf, resp.Body)
+		if mem, ok := stats["vmem"].(float64); ok {
+			w.LatestMem = float32(mem)
+		}
+		if watts, ok := stats["predictedPower"].(float64); ok {
+			w.LatestPredictedPower = float32(watts)
+		}
+		if watts, ok := stats["actualPower"].(float64); ok {
+			w.LatestActualPower = float32(watts)
+		}
+	}()
 
-		stats := make(map[string][]byte)
-		json.Unmarshal(buf.Bytes(), &stats)
-		for key := range stats {
-			var stat map[string]interface{}
-			json.Unmarshal(stats[key], &stat)
-			w.RunningJobStats[key] = stat
+	pollWaitGroup.Add(1)
+	go func() {
+		defer pollWaitGroup.Done()
+		if _, err := w.ContainerStats(ctx); err != nil {
+			mu.Lock()
+			errs = append(errs, err.Error())
+			mu.Unlock()
 		}
+	}()
+
+	pollWaitGroup.Wait()
+	if len(errs) > 0 {
+		return nil, errors.New(errs[0])
 	}
-	return nil, nil
+	return w.stats, nil
+}
+
+// ContainerStats fetches the raw docker stats of every running job and
+// caches the decoded form in RunningJobStats.
+func (w *ManagerWorker) ContainerStats(ctx context.Context) (map[string][]byte, error) {
+	stats, err := w.transport.GetRunningJobsStats(ctx)
+	if err != nil {
+		log.Print(err)
+		return nil, err
+	}
+	w.statsMu.Lock()
+	defer w.statsMu.Unlock()
+	for key := range stats {
+		var stat map[string]interface{}
+		json.Unmarshal(stats[key], &stat)
+		w.RunningJobStats[key] = stat
+	}
+	return stats, nil
 }
 
 func (w *ManagerWorker) GetStats() map[string]interface{} {
 	return w.stats
 }
 
-func (w *ManagerWorker) IsAvailable() bool {
-	if w.RPCServer {
-		var available bool
-		if err := w.rpcClient.Call("RPCServerWorker.IsAvailable", "", &available); err != nil {
-			log.Print(err)
-			return false
-		}
-	} else {
-		resp, err := http.Get(w.Address + "/available")
-		if err != nil {
-			log.Fatalln(err)
-			return false
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode != 200 {
-			return false
-		}
+// IsAvailable reports whether the worker accepts jobs. An unreachable
+// worker is reported as unavailable along with the error.
+func (w *ManagerWorker) IsAvailable(ctx context.Context) (bool, error) {
+	state, err := w.GetState(ctx)
+	if err != nil {
+		return false, err
 	}
-	return true
+	return state.Accepting(), nil
+}
+
+// GetState asks the worker for its state and records it in State and
+// Available.
+func (w *ManagerWorker) GetState(ctx context.Context) (State, error) {
+	state, err := w.transport.GetState(ctx)
+	if err != nil {
+		log.Print(err)
+		w.State = StateUnavailable
+		w.Available = false
+		return StateUnavailable, err
+	}
+	w.State = state
+	w.Available = state.Accepting()
+	return state, nil
+}
+
+// GetPowerModel fetches the model the worker predicts its power with, e.g.
+// to set it as the powerModel of meter-less workers of the same hardware.
+func (w *ManagerWorker) GetPowerModel(ctx context.Context) (*RegressionPowerModel, error) {
+	return w.transport.GetPowerModel(ctx)
+}
+
+// MeterLog fetches the readings of a meter log on the worker, e.g. the path
+// returned by StopMeter, so experiments can be analyzed without copying
+// files off the worker.
+func (w *ManagerWorker) MeterLog(ctx context.Context, q MeterLogQuery) ([]PowerReading, error) {
+	return w.transport.MeterLog(ctx, q)
+}
+
+// PowerCapActions fetches the latest actions the worker took on its jobs to
+// stay under its powerThresh.
+func (w *ManagerWorker) PowerCapActions(ctx context.Context) ([]PowerCapAction, error) {
+	return w.transport.PowerCapActions(ctx)
+}
+
+// ListJobHistory fetches the records of the jobs that stopped running on the
+// worker, oldest first.
+func (w *ManagerWorker) ListJobHistory(ctx context.Context, q HistoryQuery) ([]JobRecord, error) {
+	return w.transport.ListJobHistory(ctx, q)
+}
+
+// JobStatus asks the worker whether a job is running or how it finished:
+// succeeded or failed by its exit code, or killed by the worker for the
+// returned Reason. Jobs the worker does not know of fail with an error
+// matching ErrJobNotFound.
+func (w *ManagerWorker) JobStatus(ctx context.Context, ID string) (JobStatus, error) {
+	return w.transport.JobStatus(ctx, ID)
 }
 
 func (w *ManagerWorker) PowerMeterOn() bool {
-	if w.RPCServer {
-		var available bool
-		if err := w.rpcClient.Call("RPCServerWorker.PowerMeterOn", "", &available); err != nil {
-			log.Print(err)
-			return false
-		}
-		return available
-	} else {
-		resp, err := http.Get(w.Address + "/has-power-meter")
-		if err != nil {
-			log.Fatalln(err)
-			return false
-		}
-		defer resp.Body.Close()
-		if resp.StatusCode != 200 {
-			return false
-		}
-		buf := new(bytes.Buffer)
-		io.Copy(buf, resp.Body)
-		if strings.Contains(buf.String(), "not running") {
-			return false
-		} else {
-			return true
-		}
+	on, err := w.transport.PowerMeterOn(context.Background())
+	if err != nil {
+		log.Print(err)
+		return false
 	}
+	return on
+}
+
+// Close releases the connection to the worker.
+func (w *ManagerWorker) Close() error {
+	return w.transport.Close()
 }
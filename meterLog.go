@@ -0,0 +1,160 @@
+package worker
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errNotMeterLog = errors.New("not a power meter log")
+
+// wattsupInterval is how often the wattsup meter writes a reading.
+const wattsupInterval = time.Second
+
+// wattsupNameLayout is how wattsup names its log when no name is given.
+const wattsupNameLayout = "2006_01_02-15:04:05"
+
+// wattsupStartSuffix names the file next to a wattsup log holding the time
+// the meter was started. The log is created, and named, when the previous
+// run stops or the worker starts, which can be long before.
+const wattsupStartSuffix = ".start"
+
+// PowerReading is one sample taken by a power meter.
+type PowerReading struct {
+	Time  time.Time `json:"time"`
+	Watts float32   `json:"watts"`
+}
+
+// ReadWattsupLog parses a log written by the wattsup meter. The meter
+// writes one reading per line and no timestamps, so readings are timed one
+// wattsupInterval apart from the start time recorded by WattsupMeter.Start.
+// Logs without one are timed from the time encoded in their name, or back
+// from the file's modification time when the name carries none.
+func ReadWattsupLog(path string) ([]PowerReading, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var watts []float32
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if w, ok := parseWatts(scanner.Text()); ok {
+			watts = append(watts, w)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	start, err := readWattsupStart(path)
+	if err != nil {
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		start, err = time.ParseInLocation(wattsupNameLayout, name, time.Local)
+	}
+	if err != nil {
+		info, err := f.Stat()
+		if err != nil {
+			return nil, err
+		}
+		start = info.ModTime().Add(-time.Duration(len(watts)) * wattsupInterval)
+	}
+
+	readings := make([]PowerReading, len(watts))
+	for i, w := range watts {
+		readings[i] = PowerReading{Time: start.Add(time.Duration(i) * wattsupInterval), Watts: w}
+	}
+	return readings, nil
+}
+
+func writeWattsupStart(path string, t time.Time) error {
+	return os.WriteFile(path+wattsupStartSuffix, []byte(t.Format(time.RFC3339Nano)), 0644)
+}
+
+func readWattsupStart(path string) (time.Time, error) {
+	buf, err := os.ReadFile(path + wattsupStartSuffix)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339Nano, strings.TrimSpace(string(buf)))
+}
+
+// parseWatts reads the wattage on a line of meter output, which is its
+// last field.
+func parseWatts(line string) (float32, bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	w, err := strconv.ParseFloat(strings.TrimSuffix(fields[len(fields)-1], ","), 32)
+	if err != nil {
+		return 0, false
+	}
+	return float32(w), true
+}
+
+// MeterLogQuery selects the readings returned by MeterLog.
+type MeterLogQuery struct {
+	// Path is a log returned by StopMeter, the running meter's log when
+	// empty
+	Path string `json:"path,omitempty"`
+	// From and To bound the readings returned when they are not zero
+	From time.Time `json:"from,omitempty"`
+	To   time.Time `json:"to,omitempty"`
+}
+
+func (q MeterLogQuery) includes(t time.Time) bool {
+	return (q.From.IsZero() || !t.Before(q.From)) && (q.To.IsZero() || !t.After(q.To))
+}
+
+// MeterLog reads the readings of a power meter log on the worker. Only logs
+// in the meter's directory can be read. Meters keeping no log return the
+// readings of their current run, and the readings of the running meter's
+// log are timed when they were read as far as they are kept in memory.
+func (w *ServerWorker) MeterLog(ctx context.Context, q MeterLogQuery) ([]PowerReading, error) {
+	if !w.HasPowerMeter {
+		return nil, errNoPowerMeter
+	}
+	current := w.GetMeterPath()
+	path := q.Path
+	if path == "" {
+		path = current
+	} else if current == "" || filepath.Dir(filepath.Clean(path)) != filepath.Dir(filepath.Clean(current)) {
+		return nil, fmt.Errorf("%w: %s", errNotMeterLog, path)
+	}
+	var readings []PowerReading
+	if path == "" {
+		readings = w.meter.Samples()
+	} else {
+		var err error
+		if readings, err = ReadWattsupLog(path); err != nil {
+			return nil, err
+		}
+		if filepath.Clean(path) == filepath.Clean(current) && w.meter.Running() {
+			readings = withSamples(readings, w.meter.Samples())
+		}
+	}
+	selected := readings[:0]
+	for _, r := range readings {
+		if q.includes(r.Time) {
+			selected = append(selected, r)
+		}
+	}
+	return selected, nil
+}
+
+// withSamples replaces the latest readings of a running meter's log with
+// the samples taken as they were written, which the log holds the last of.
+func withSamples(readings, samples []PowerReading) []PowerReading {
+	if len(samples) >= len(readings) {
+		return samples
+	}
+	return append(readings[:len(readings)-len(samples)], samples...)
+}
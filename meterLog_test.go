@@ -0,0 +1,101 @@
+package worker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadWattsupLog(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local)
+	modTime := start.Add(time.Hour)
+	tests := []struct {
+		name      string
+		file      string
+		withStart bool
+		want      time.Time
+	}{
+		{"start file", "meter.log", true, start},
+		{"start file over name", "2020_01_01-00:00:00.log", true, start},
+		{"name", "2024_03_01-12:00:00.log", false, start},
+		{"modification time", "meter.log", false, modTime.Add(-3 * wattsupInterval)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte("watts\n80.5\n\n100,\n120\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chtimes(path, modTime, modTime); err != nil {
+				t.Fatal(err)
+			}
+			if tt.withStart {
+				if err := writeWattsupStart(path, start); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			readings, err := ReadWattsupLog(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []PowerReading{
+				{Time: tt.want, Watts: 80.5},
+				{Time: tt.want.Add(wattsupInterval), Watts: 100},
+				{Time: tt.want.Add(2 * wattsupInterval), Watts: 120},
+			}
+			if !equalReadings(readings, want) {
+				t.Errorf("ReadWattsupLog() = %v, want %v", readings, want)
+			}
+		})
+	}
+
+	if _, err := ReadWattsupLog(filepath.Join(t.TempDir(), "missing.log")); !os.IsNotExist(err) {
+		t.Errorf("ReadWattsupLog() error = %v, want a missing file", err)
+	}
+}
+
+func TestParseWatts(t *testing.T) {
+	tests := []struct {
+		line   string
+		want   float32
+		wantOK bool
+	}{
+		{"120.5", 120.5, true},
+		{"2024-03-01 12:00:00 98", 98, true},
+		{"power: 42,", 42, true},
+		{"", 0, false},
+		{"watts", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseWatts(tt.line)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseWatts(%q) = %g, %v, want %g, %v", tt.line, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestWithSamples(t *testing.T) {
+	t0 := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	reading := func(s int, watts float32) PowerReading {
+		return PowerReading{Time: t0.Add(time.Duration(s) * time.Second), Watts: watts}
+	}
+	tests := []struct {
+		name     string
+		readings []PowerReading
+		samples  []PowerReading
+		want     []PowerReading
+	}{
+		{"no samples", []PowerReading{reading(0, 1), reading(1, 2)}, nil, []PowerReading{reading(0, 1), reading(1, 2)}},
+		{"latest replaced", []PowerReading{reading(0, 1), reading(1, 2), reading(2, 3)}, []PowerReading{reading(5, 20), reading(6, 30)}, []PowerReading{reading(0, 1), reading(5, 20), reading(6, 30)}},
+		{"all replaced", []PowerReading{reading(0, 1)}, []PowerReading{reading(5, 20), reading(6, 30)}, []PowerReading{reading(5, 20), reading(6, 30)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withSamples(tt.readings, tt.samples); !equalReadings(got, tt.want) {
+				t.Errorf("withSamples() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
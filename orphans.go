@@ -0,0 +1,111 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	job "github.com/Nguyen-Hoa/job"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// Orphan policies, for containers the worker did not start.
+const (
+	OrphanKill   = "kill"
+	OrphanAdopt  = "adopt"
+	OrphanIgnore = "ignore"
+)
+
+// OrphanConfig sets which containers the worker manages, and what it does
+// with the ones it did not start.
+type OrphanConfig struct {
+	// Policy is kill, to stop them, adopt, to track them as jobs, or
+	// ignore, to leave them alone. kill when empty.
+	Policy string `json:"policy,omitempty"`
+	// Duration is the time adopted containers may run, in seconds. They
+	// are never killed when 0.
+	Duration int `json:"duration,omitempty"`
+	// Labels select the containers the worker manages: only those carrying
+	// all of them are listed, and thus ever killed. Jobs started by the
+	// worker are given them.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+func (c OrphanConfig) validate() error {
+	switch c.Policy {
+	case "", OrphanKill, OrphanAdopt, OrphanIgnore:
+		return nil
+	}
+	return fmt.Errorf("unknown orphan policy %q", c.Policy)
+}
+
+// listContainers lists the running containers started by a worker of the
+// same name and carrying the worker's selector labels.
+func (w *ServerWorker) listContainers(ctx context.Context) ([]types.Container, error) {
+	args := filters.NewArgs()
+	if w.Name != "" {
+		args.Add("label", LabelWorker+"="+w.Name)
+	} else {
+		args.Add("label", LabelWorker)
+	}
+	for k, v := range w.config.Orphans.Labels {
+		args.Add("label", k+"="+v)
+	}
+	return w._docker.ContainerList(ctx, types.ContainerListOptions{Filters: args})
+}
+
+// jobLabels returns the labels of j along with the worker's selector and
+// owner labels, which win over the job's.
+func (w *ServerWorker) jobLabels(j JobSpec, owner map[string]string) map[string]string {
+	labels := make(map[string]string, len(j.Labels)+len(w.config.Orphans.Labels)+len(owner))
+	for _, m := range []map[string]string{j.Labels, w.config.Orphans.Labels, owner} {
+		for k, v := range m {
+			labels[k] = v
+		}
+	}
+	return labels
+}
+
+// handleOrphan applies the orphan policy to a container missing from
+// RunningJobs, e.g. one started before the worker restarted, and reports
+// whether the worker now tracks it.
+func (w *ServerWorker) handleOrphan(container types.Container) bool {
+	switch w.config.Orphans.Policy {
+	case OrphanIgnore:
+		return false
+	case OrphanAdopt:
+		adopted := job.DockerJob{
+			BaseJob: job.BaseJob{
+				StartTime: time.Unix(container.Created, 0),
+				Duration:  time.Duration(w.config.Orphans.Duration) * time.Second,
+			},
+			Container: container,
+		}
+		// TotalRunTime stays 0: updateRunningJobs adds the time since
+		// StartTime on every pass
+		log.Printf("adopting orphan container %s", container.ID)
+		w.setJob(&w.RunningJobs, container.ID, adopted)
+		w.history.startedContainer(adopted)
+		w.watchRunningJob(container.ID)
+		return true
+	default:
+		orphan := job.DockerJob{
+			BaseJob: job.BaseJob{
+				StartTime:    time.Now(),
+				TotalRunTime: time.Duration(0),
+				Duration:     time.Duration(-1),
+			},
+			Container: types.Container{ID: container.ID},
+		}
+		w.setJob(&w.jobsToKill, orphan.ID, orphan)
+		w.setJob(&w.RunningJobs, container.ID, orphan)
+		w.history.startedContainer(job.DockerJob{
+			BaseJob:   job.BaseJob{StartTime: time.Unix(container.Created, 0)},
+			Container: container,
+		})
+		w.watchRunningJob(container.ID)
+		return true
+	}
+}
@@ -0,0 +1,278 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// maxPowerCapActions is how many actions PowerCapActions returns.
+const maxPowerCapActions = 1000
+
+const (
+	defaultPowerCapInterval = 5 * time.Second
+	defaultPowerCapMargin   = 0.1
+	defaultPowerCapMinCPUs  = 0.1
+)
+
+// PowerCapConfig sets what the worker does to its jobs while its power is
+// over powerThresh.
+type PowerCapConfig struct {
+	// Policy is throttle, to halve the CPU limit of the newest job, pause,
+	// to pause it, or stop, to stop it. Power is not capped when empty.
+	Policy string `json:"policy,omitempty"`
+	// Interval is the time between checks, in seconds, 5 when 0.
+	Interval float64 `json:"interval,omitempty"`
+	// Margin is how far power must drop under powerThresh, as a fraction
+	// of it, before jobs are resumed, 0.1 when 0.
+	Margin float64 `json:"margin,omitempty"`
+	// MinCPUs is the least a job is throttled to, 0.1 when 0.
+	MinCPUs float64 `json:"minCpus,omitempty"`
+}
+
+// PowerCapAction is something the worker did to a job to cap its power.
+type PowerCapAction struct {
+	Time  time.Time `json:"time"`
+	JobID string    `json:"jobId"`
+	// Action is throttle, pause, stop, unthrottle or unpause
+	Action string  `json:"action"`
+	Power  float32 `json:"power"`
+	Thresh float32 `json:"thresh"`
+	// CPUs is the job's CPU limit after a throttle or unthrottle
+	CPUs float64 `json:"cpus,omitempty"`
+}
+
+// cappedJob is a job throttled or paused by the power capper, along with
+// what to restore it to.
+type cappedJob struct {
+	ID       string
+	Action   string
+	original int64
+	current  int64
+}
+
+type powerCapper struct {
+	mu      sync.Mutex
+	capped  []*cappedJob
+	actions []PowerCapAction
+}
+
+func (c *powerCapper) record(a PowerCapAction) {
+	log.Printf("power %.1f W, threshold %.1f W: %s %s", a.Power, a.Thresh, a.Action, a.JobID)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.actions = append(c.actions, a)
+	if len(c.actions) > maxPowerCapActions {
+		c.actions = c.actions[len(c.actions)-maxPowerCapActions:]
+	}
+}
+
+func (c *powerCapper) find(ID string) *cappedJob {
+	for _, j := range c.capped {
+		if j.ID == ID {
+			return j
+		}
+	}
+	return nil
+}
+
+// RunPowerCap checks the worker's power every interval and applies the
+// power cap policy until ctx is done.
+func (w *ServerWorker) RunPowerCap(ctx context.Context) error {
+	config := w.config.PowerCap
+	if config.Policy == "" || w.PowerThresh <= 0 {
+		return errors.New("power capping needs a powerCap policy and a powerThresh")
+	}
+	switch config.Policy {
+	case "throttle", "pause", "stop":
+	default:
+		return fmt.Errorf("unknown power cap policy %q", config.Policy)
+	}
+	interval := defaultPowerCapInterval
+	if config.Interval > 0 {
+		interval = time.Duration(config.Interval * float64(time.Second))
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			w.capPower(ctx)
+		}
+	}
+}
+
+// capPower caps one more job while power is over the threshold, and
+// resumes the job capped last once it is back under it.
+func (w *ServerWorker) capPower(ctx context.Context) {
+	if _, ok := w.liveReading(); !ok {
+		// the prediction is only as fresh as the stats
+		if err := w.sampleUtilization(ctx); err != nil {
+			log.Print(err)
+		}
+	}
+	margin := w.config.PowerCap.Margin
+	if margin <= 0 {
+		margin = defaultPowerCapMargin
+	}
+	power := w.currentPower()
+	if power > w.PowerThresh {
+		w.capJob(ctx, power)
+	} else if float64(power) < float64(w.PowerThresh)*(1-margin) {
+		w.uncapJob(ctx, power)
+	}
+}
+
+// newestJobs returns the running jobs, newest first.
+func (w *ServerWorker) newestJobs() []string {
+	jobs := w.runningJobs()
+	ids := make([]string, 0, len(jobs))
+	for id := range jobs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return jobs[ids[i]].StartTime.After(jobs[ids[j]].StartTime)
+	})
+	return ids
+}
+
+func (w *ServerWorker) capJob(ctx context.Context, power float32) {
+	c := &w.powerCap
+	policy := w.config.PowerCap.Policy
+	for _, id := range w.newestJobs() {
+		action := PowerCapAction{Time: time.Now(), JobID: id, Action: policy, Power: power, Thresh: w.PowerThresh}
+		c.mu.Lock()
+		capped := c.find(id)
+		c.mu.Unlock()
+
+		switch policy {
+		case "stop":
+			if _, err := w.stopJob(ctx, id, ReasonPowerCapped); err != nil {
+				log.Print(err)
+				continue
+			}
+			w.deleteJob(&w.RunningJobs, id)
+		case "pause":
+			if capped != nil {
+				continue
+			}
+			if err := w._docker.ContainerPause(ctx, id); err != nil {
+				log.Print(err)
+				continue
+			}
+			c.mu.Lock()
+			c.capped = append(c.capped, &cappedJob{ID: id, Action: "pause"})
+			c.mu.Unlock()
+		case "throttle":
+			if capped == nil {
+				nano, err := w.nanoCPUs(ctx, id)
+				if err != nil {
+					log.Print(err)
+					continue
+				}
+				capped = &cappedJob{ID: id, Action: "throttle", original: nano, current: nano}
+			}
+			next := capped.current / 2
+			if float64(next) < w.minThrottleCPUs()*1e9 {
+				continue
+			}
+			if err := w.setNanoCPUs(ctx, id, next); err != nil {
+				log.Print(err)
+				continue
+			}
+			c.mu.Lock()
+			if c.find(id) == nil {
+				c.capped = append(c.capped, capped)
+			}
+			capped.current = next
+			c.mu.Unlock()
+			action.CPUs = float64(next) / 1e9
+		}
+		c.record(action)
+		return
+	}
+	log.Printf("power %.1f W over threshold %.1f W, no job left to %s", power, w.PowerThresh, policy)
+}
+
+// uncapJob restores the job capped last, dropping those that are gone.
+func (w *ServerWorker) uncapJob(ctx context.Context, power float32) {
+	c := &w.powerCap
+	for {
+		c.mu.Lock()
+		if len(c.capped) == 0 {
+			c.mu.Unlock()
+			return
+		}
+		j := c.capped[len(c.capped)-1]
+		c.capped = c.capped[:len(c.capped)-1]
+		c.mu.Unlock()
+		if !w.verifyContainer(j.ID) {
+			continue
+		}
+
+		action := PowerCapAction{Time: time.Now(), JobID: j.ID, Power: power, Thresh: w.PowerThresh}
+		var err error
+		if j.Action == "pause" {
+			action.Action = "unpause"
+			err = w._docker.ContainerUnpause(ctx, j.ID)
+		} else {
+			action.Action = "unthrottle"
+			action.CPUs = float64(j.original) / 1e9
+			err = w.setNanoCPUs(ctx, j.ID, j.original)
+		}
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		c.record(action)
+		return
+	}
+}
+
+// nanoCPUs returns the CPU limit of a job, all of the worker's cores when
+// it has none.
+func (w *ServerWorker) nanoCPUs(ctx context.Context, ID string) (int64, error) {
+	info, err := w._docker.ContainerInspect(ctx, ID)
+	if err != nil {
+		return 0, err
+	}
+	if info.ContainerJSONBase != nil && info.HostConfig != nil && info.HostConfig.NanoCPUs > 0 {
+		return info.HostConfig.NanoCPUs, nil
+	}
+	cores := w.Cores
+	if cores <= 0 {
+		cores = w.ncpu
+	}
+	return int64(cores) * 1e9, nil
+}
+
+func (w *ServerWorker) setNanoCPUs(ctx context.Context, ID string, nano int64) error {
+	_, err := w._docker.ContainerUpdate(ctx, ID, container.UpdateConfig{
+		Resources: container.Resources{NanoCPUs: nano},
+	})
+	return err
+}
+
+func (w *ServerWorker) minThrottleCPUs() float64 {
+	if min := w.config.PowerCap.MinCPUs; min > 0 {
+		return min
+	}
+	return defaultPowerCapMinCPUs
+}
+
+// PowerCapActions returns the latest actions taken to cap the worker's
+// power, oldest first.
+func (w *ServerWorker) PowerCapActions(ctx context.Context) ([]PowerCapAction, error) {
+	w.powerCap.mu.Lock()
+	defer w.powerCap.mu.Unlock()
+	return append([]PowerCapAction(nil), w.powerCap.actions...), nil
+}
@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	job "github.com/Nguyen-Hoa/job"
+	"github.com/docker/docker/api/types"
+)
+
+func TestCapPowerStoppedMeter(t *testing.T) {
+	w := &ServerWorker{}
+	w.PowerThresh = 90
+	w.config.PowerCap = PowerCapConfig{Policy: "stop"}
+	w.RunningJobs.Init()
+	w.setJob(&w.RunningJobs, "running", job.DockerJob{
+		BaseJob:   job.BaseJob{StartTime: time.Now()},
+		Container: types.Container{ID: "running"},
+	})
+	// predicts 10 to 50 W, always under the threshold
+	w.SetPowerModel(&LinearPowerModel{Idle: 10, Max: 50})
+
+	meter := NewFakePowerMeter(120, time.Second)
+	if err := meter.Start(); err != nil {
+		t.Fatal(err)
+	}
+	meter.Record(PowerReading{Time: time.Now(), Watts: 120})
+	if err := meter.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	w.meter = meter
+	w.HasPowerMeter = true
+
+	for i := 0; i < 3; i++ {
+		w.capPower(context.Background())
+	}
+	if actions, _ := w.PowerCapActions(context.Background()); len(actions) != 0 {
+		t.Errorf("capPower() took %v, want no action", actions)
+	}
+	if !w.verifyContainer("running") {
+		t.Error("capPower() stopped the job, want it running")
+	}
+	if power := w.currentPower(); power > 50 {
+		t.Errorf("currentPower() = %g, want the prediction", power)
+	}
+}
@@ -0,0 +1,400 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// powerAverageWindow is the span of the rolling average power in stats.
+const powerAverageWindow = time.Minute
+
+// maxMeterSamples is how many readings a meter keeps, an hour at one
+// reading per second.
+const maxMeterSamples = 3600
+
+// maxReadingAge is how many meter intervals the latest reading stands for
+// the machine's power.
+const maxReadingAge = 3
+
+// maxFailedReads is how many reads in a row a meter may fail before it
+// reports itself unhealthy.
+const maxFailedReads = 3
+
+// PowerMeter measures the power drawn by the machine.
+type PowerMeter interface {
+	Start() error
+	Stop() error
+	Running() bool
+	// Interval is the time between readings.
+	Interval() time.Duration
+	// Latest returns the last reading, or false before the first one.
+	Latest() (PowerReading, bool)
+	// Samples returns the readings taken since the meter started, at most
+	// the last maxMeterSamples.
+	Samples() []PowerReading
+}
+
+// HealthReporter is implemented by meters that can fail while running. The
+// worker is degraded while Err returns an error.
+type HealthReporter interface {
+	Err() error
+}
+
+// LoggingPowerMeter is a PowerMeter writing its readings to a log, which
+// StopMeter returns and MeterLog reads.
+type LoggingPowerMeter interface {
+	PowerMeter
+	Path() string
+}
+
+// PowerMeterConfig selects the worker's power meter.
+type PowerMeterConfig struct {
+	// Type is wattsup, rapl, command, file or fake. When empty, the
+	// wattsup meter is used if wattsup.path is set, and none otherwise.
+	Type string `json:"type,omitempty"`
+	// Interval is the time between readings of the rapl, command, file and
+	// fake meters, in seconds, 1 when 0.
+	Interval float64 `json:"interval,omitempty"`
+	// RAPLPath is the powercap directory, /sys/class/powercap when empty.
+	RAPLPath string `json:"raplPath,omitempty"`
+	// Command prints the current watts as the last field of its output.
+	Command string `json:"command,omitempty"`
+	// File holds the current watts, e.g. as exported by a PDU.
+	File string `json:"file,omitempty"`
+	// Watts is the reading of the fake meter.
+	Watts float32 `json:"watts,omitempty"`
+}
+
+// newPowerMeter builds the meter selected by config, nil when there is none.
+func newPowerMeter(config WorkerConfig) (PowerMeter, error) {
+	c := config.PowerMeter
+	interval := wattsupInterval
+	if c.Interval > 0 {
+		interval = time.Duration(c.Interval * float64(time.Second))
+	}
+	switch c.Type {
+	case "":
+		if config.Wattsup.Path == "" {
+			return nil, nil
+		}
+		fallthrough
+	case "wattsup":
+		return NewWattsupMeter(config.Wattsup), nil
+	case "rapl":
+		return NewRAPLMeter(c.RAPLPath, interval)
+	case "command":
+		return NewCommandMeter(c.Command, interval)
+	case "file":
+		return NewFileMeter(c.File, interval)
+	case "fake":
+		return NewFakePowerMeter(c.Watts, interval), nil
+	default:
+		return nil, fmt.Errorf("unknown power meter type %q", c.Type)
+	}
+}
+
+// readingLog keeps the latest readings of a meter.
+type readingLog struct {
+	mu       sync.Mutex
+	readings []PowerReading
+}
+
+func (l *readingLog) add(r PowerReading) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.readings = append(l.readings, r)
+	if len(l.readings) > maxMeterSamples {
+		l.readings = l.readings[len(l.readings)-maxMeterSamples:]
+	}
+}
+
+func (l *readingLog) reset() {
+	l.mu.Lock()
+	l.readings = nil
+	l.mu.Unlock()
+}
+
+func (l *readingLog) latest() (PowerReading, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.readings) == 0 {
+		return PowerReading{}, false
+	}
+	return l.readings[len(l.readings)-1], true
+}
+
+func (l *readingLog) samples() []PowerReading {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]PowerReading(nil), l.readings...)
+}
+
+// meterHealth counts the reads of a meter failing in a row.
+type meterHealth struct {
+	mu       sync.Mutex
+	failures int
+	err      error
+}
+
+func (h *meterHealth) record(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err == nil {
+		h.failures, h.err = 0, nil
+		return
+	}
+	h.failures++
+	if h.failures >= maxFailedReads {
+		h.err = fmt.Errorf("power meter failed %d reads: %w", h.failures, err)
+	}
+}
+
+// fail reports the meter unhealthy right away.
+func (h *meterHealth) fail(err error) {
+	h.mu.Lock()
+	h.err = err
+	h.mu.Unlock()
+}
+
+func (h *meterHealth) reset() {
+	h.record(nil)
+}
+
+// Err returns the last error once maxFailedReads reads failed in a row.
+func (h *meterHealth) Err() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.err
+}
+
+// PollingMeter takes a reading every interval from a source that reports
+// the current watts on demand.
+type PollingMeter struct {
+	interval time.Duration
+	read     func() (float32, bool, error)
+	log      readingLog
+	health   meterHealth
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newPollingMeter polls read, which returns false when it has no reading
+// yet.
+func newPollingMeter(interval time.Duration, read func() (float32, bool, error)) *PollingMeter {
+	return &PollingMeter{interval: interval, read: read}
+}
+
+func (m *PollingMeter) Start() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stop != nil {
+		return errors.New("power meter already running")
+	}
+	// the first read only primes sources reporting averages since the
+	// previous one
+	if _, _, err := m.read(); err != nil {
+		return err
+	}
+	m.log.reset()
+	m.health.reset()
+	m.stop, m.done = make(chan struct{}), make(chan struct{})
+	go m.poll(m.stop, m.done)
+	return nil
+}
+
+func (m *PollingMeter) poll(stop chan struct{}, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			watts, ok, err := m.read()
+			m.health.record(err)
+			if err != nil {
+				log.Print(err)
+			} else if ok {
+				m.log.add(PowerReading{Time: now, Watts: watts})
+			}
+		}
+	}
+}
+
+func (m *PollingMeter) Stop() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stop == nil {
+		return errors.New("power meter not running")
+	}
+	close(m.stop)
+	<-m.done
+	m.stop, m.done = nil, nil
+	return nil
+}
+
+func (m *PollingMeter) Running() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stop != nil
+}
+
+func (m *PollingMeter) Interval() time.Duration {
+	return m.interval
+}
+
+func (m *PollingMeter) Latest() (PowerReading, bool) {
+	return m.log.latest()
+}
+
+func (m *PollingMeter) Samples() []PowerReading {
+	return m.log.samples()
+}
+
+func (m *PollingMeter) Err() error {
+	return m.health.Err()
+}
+
+// NewCommandMeter runs command every interval and reads the watts as the
+// last field of its output.
+func NewCommandMeter(command string, interval time.Duration) (*PollingMeter, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil, errors.New("command power meter needs a command")
+	}
+	return newPollingMeter(interval, func() (float32, bool, error) {
+		out, err := exec.Command(args[0], args[1:]...).Output()
+		if err != nil {
+			return 0, false, err
+		}
+		watts, ok := parseWatts(string(out))
+		if !ok {
+			return 0, false, fmt.Errorf("no watts in output of %s: %q", args[0], out)
+		}
+		return watts, true, nil
+	}), nil
+}
+
+// NewFileMeter reads the watts from path every interval.
+func NewFileMeter(path string, interval time.Duration) (*PollingMeter, error) {
+	if path == "" {
+		return nil, errors.New("file power meter needs a file")
+	}
+	return newPollingMeter(interval, func() (float32, bool, error) {
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			return 0, false, err
+		}
+		watts, ok := parseWatts(string(buf))
+		if !ok {
+			return 0, false, fmt.Errorf("no watts in %s: %q", path, buf)
+		}
+		return watts, true, nil
+	}), nil
+}
+
+// FakePowerMeter reads whatever watts it was last set to, for testing
+// workers without a meter.
+type FakePowerMeter struct {
+	*PollingMeter
+	mu    sync.Mutex
+	watts float32
+}
+
+func NewFakePowerMeter(watts float32, interval time.Duration) *FakePowerMeter {
+	m := &FakePowerMeter{watts: watts}
+	m.PollingMeter = newPollingMeter(interval, func() (float32, bool, error) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return m.watts, true, nil
+	})
+	return m
+}
+
+// Set changes the watts of the following readings.
+func (m *FakePowerMeter) Set(watts float32) {
+	m.mu.Lock()
+	m.watts = watts
+	m.mu.Unlock()
+}
+
+// Record adds a reading directly, e.g. one timed in the past.
+func (m *FakePowerMeter) Record(r PowerReading) {
+	m.log.add(r)
+}
+
+// meterEnergy integrates the readings of the worker's meter into joules.
+type meterEnergy struct {
+	mu     sync.Mutex
+	last   PowerReading
+	joules float64
+}
+
+func (e *meterEnergy) reset() {
+	e.mu.Lock()
+	e.last, e.joules = PowerReading{}, 0
+	e.mu.Unlock()
+}
+
+// update adds the readings taken since the last update, and returns the
+// energy measured since the meter started.
+func (e *meterEnergy) update(readings []PowerReading) float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range readings {
+		if !r.Time.After(e.last.Time) {
+			continue
+		}
+		if !e.last.Time.IsZero() {
+			e.joules += float64(e.last.Watts) * r.Time.Sub(e.last.Time).Seconds()
+		}
+		e.last = r
+	}
+	return e.joules
+}
+
+// liveReading returns the latest reading of the meter while it runs, unless
+// the meter has not read for maxReadingAge intervals.
+func (w *ServerWorker) liveReading() (PowerReading, bool) {
+	if w.meter == nil || !w.meter.Running() {
+		return PowerReading{}, false
+	}
+	r, ok := w.meter.Latest()
+	if !ok || time.Since(r.Time) > maxReadingAge*w.meter.Interval() {
+		return PowerReading{}, false
+	}
+	return r, true
+}
+
+// recordPower adds the meter's readings to stats as "actualPower",
+// "averagePower" over powerAverageWindow (watts) and "energy" since the
+// meter started (joules), and keeps LatestActualPower in line with them.
+func (w *ServerWorker) recordPower(stats map[string]interface{}) {
+	if w.meter == nil || !w.meter.Running() {
+		return
+	}
+	readings := w.meter.Samples()
+	if len(readings) == 0 {
+		return
+	}
+	last := readings[len(readings)-1]
+	var sum float32
+	count := 0
+	for i := len(readings) - 1; i >= 0 && last.Time.Sub(readings[i].Time) < powerAverageWindow; i-- {
+		sum += readings[i].Watts
+		count++
+	}
+	w.LatestActualPower = last.Watts
+	stats["actualPower"] = float64(last.Watts)
+	stats["averagePower"] = float64(sum / float32(count))
+	stats["energy"] = w.meterEnergy.update(readings)
+}
@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMeterHealth(t *testing.T) {
+	var h meterHealth
+	failed := errors.New("read failed")
+	for i := 1; i < maxFailedReads; i++ {
+		h.record(failed)
+		if err := h.Err(); err != nil {
+			t.Fatalf("Err() after %d failed reads = %v, want nil", i, err)
+		}
+	}
+	h.record(failed)
+	if err := h.Err(); !errors.Is(err, failed) {
+		t.Fatalf("Err() after %d failed reads = %v, want %v", maxFailedReads, err, failed)
+	}
+	h.record(nil)
+	if err := h.Err(); err != nil {
+		t.Errorf("Err() after a good read = %v, want nil", err)
+	}
+}
+
+func TestPollingMeter(t *testing.T) {
+	m := NewFakePowerMeter(100, time.Millisecond)
+	if err := m.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Stop()
+	if err := m.Start(); err == nil {
+		t.Error("Start() of a running meter, want an error")
+	}
+
+	waitFor := func(what string, cond func() bool) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for !cond() {
+			if time.Now().After(deadline) {
+				t.Fatalf("no %s after 5s", what)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	waitFor("reading", func() bool {
+		r, ok := m.Latest()
+		return ok && r.Watts == 100
+	})
+	m.Set(150)
+	waitFor("reading of the new watts", func() bool {
+		r, ok := m.Latest()
+		return ok && r.Watts == 150
+	})
+	if err := m.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+
+	// the priming read of Start succeeds, the polled ones fail
+	var reads int32
+	failing := newPollingMeter(time.Millisecond, func() (float32, bool, error) {
+		if atomic.AddInt32(&reads, 1) == 1 {
+			return 0, false, nil
+		}
+		return 0, false, errors.New("read failed")
+	})
+	if err := failing.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer failing.Stop()
+	waitFor("error", func() bool { return failing.Err() != nil })
+	if _, ok := failing.Latest(); ok {
+		t.Error("Latest() of a failing meter = true, want false")
+	}
+}
+
+func TestStopMeterTwice(t *testing.T) {
+	w := &ServerWorker{}
+	w.meter = NewFakePowerMeter(100, time.Second)
+	w.HasPowerMeter = true
+	if err := w.StartMeter(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := w.StopMeter(context.Background()); err != nil {
+			t.Fatalf("StopMeter() #%d error = %v", i+1, err)
+		}
+	}
+	if err := w.meterError(); err != nil {
+		t.Errorf("meterError() after stopping twice = %v, want nil", err)
+	}
+}
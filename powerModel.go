@@ -0,0 +1,245 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+)
+
+// PowerModel predicts the power draw of a machine, in watts, from the stats
+// returned by Stats or ReducedStats.
+type PowerModel interface {
+	Predict(stats map[string]interface{}) (float32, error)
+}
+
+// LinearPowerModel interpolates between the idle and maximum power of the
+// machine by its CPU utilization.
+type LinearPowerModel struct {
+	Idle float32 `json:"idle"`
+	Max  float32 `json:"max"`
+}
+
+// NewLinearPowerModel builds a linear model from a worker's DynamicRange,
+// [idle, max] watts.
+func NewLinearPowerModel(dynamicRange []float32) (*LinearPowerModel, error) {
+	if len(dynamicRange) != 2 {
+		return nil, fmt.Errorf("dynamic range needs [idle, max] watts, got %v", dynamicRange)
+	}
+	if dynamicRange[1] < dynamicRange[0] {
+		return nil, fmt.Errorf("dynamic range max %.2f is below idle %.2f", dynamicRange[1], dynamicRange[0])
+	}
+	return &LinearPowerModel{Idle: dynamicRange[0], Max: dynamicRange[1]}, nil
+}
+
+func (m *LinearPowerModel) Predict(stats map[string]interface{}) (float32, error) {
+	cpu, ok := toFloat(stats["cpupercent"])
+	if !ok {
+		return 0, errors.New("stats have no cpupercent")
+	}
+	util := float32(math.Max(0, math.Min(cpu/100, 1)))
+	return m.Idle + util*(m.Max-m.Idle), nil
+}
+
+// RegressionPowerModel is a linear regression of power over any numeric
+// stats, fit with FitRegressionPowerModel.
+type RegressionPowerModel struct {
+	Intercept    float64            `json:"intercept"`
+	Coefficients map[string]float64 `json:"coefficients"`
+}
+
+// equal reports whether m and o predict the same power from any stats.
+func (m *RegressionPowerModel) equal(o *RegressionPowerModel) bool {
+	if m.Intercept != o.Intercept || len(m.Coefficients) != len(o.Coefficients) {
+		return false
+	}
+	for feature, coef := range m.Coefficients {
+		if c, ok := o.Coefficients[feature]; !ok || c != coef {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *RegressionPowerModel) Predict(stats map[string]interface{}) (float32, error) {
+	watts := m.Intercept
+	for feature, coef := range m.Coefficients {
+		v, ok := toFloat(stats[feature])
+		if !ok {
+			return 0, fmt.Errorf("stats have no %s", feature)
+		}
+		watts += coef * v
+	}
+	return float32(watts), nil
+}
+
+// PowerSample pairs the stats of a machine with the power it drew at the
+// time.
+type PowerSample struct {
+	Stats map[string]interface{}
+	Watts float32
+}
+
+// TimedStats are stats returned by Stats or ReducedStats along with the
+// time they were taken at.
+type TimedStats struct {
+	Time  time.Time
+	Stats map[string]interface{}
+}
+
+// PairPowerSamples matches each of stats with the meter reading closest in
+// time, dropping stats with no reading within tolerance.
+func PairPowerSamples(readings []PowerReading, stats []TimedStats, tolerance time.Duration) []PowerSample {
+	sorted := append([]PowerReading(nil), readings...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	samples := make([]PowerSample, 0, len(stats))
+	for _, s := range stats {
+		i := sort.Search(len(sorted), func(i int) bool { return !sorted[i].Time.Before(s.Time) })
+		best, bestDiff := -1, tolerance
+		for _, j := range []int{i - 1, i} {
+			if j < 0 || j >= len(sorted) {
+				continue
+			}
+			diff := sorted[j].Time.Sub(s.Time)
+			if diff < 0 {
+				diff = -diff
+			}
+			if diff <= bestDiff {
+				best, bestDiff = j, diff
+			}
+		}
+		if best >= 0 {
+			samples = append(samples, PowerSample{Stats: s.Stats, Watts: sorted[best].Watts})
+		}
+	}
+	return samples
+}
+
+// FitRegressionPowerModel fits power against features by least squares.
+// Samples missing any of the features are skipped.
+func FitRegressionPowerModel(samples []PowerSample, features []string) (*RegressionPowerModel, error) {
+	n := len(features) + 1
+	// normal equations (XᵀX)β = Xᵀy, with an intercept column of ones
+	xtx := make([][]float64, n)
+	for i := range xtx {
+		xtx[i] = make([]float64, n+1)
+	}
+	used := 0
+	row := make([]float64, n)
+	for _, s := range samples {
+		row[0] = 1
+		ok := true
+		for i, f := range features {
+			if row[i+1], ok = toFloat(s.Stats[f]); !ok {
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+		used++
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				xtx[i][j] += row[i] * row[j]
+			}
+			xtx[i][n] += row[i] * float64(s.Watts)
+		}
+	}
+	if used < n {
+		return nil, fmt.Errorf("need at least %d samples to fit %d features, have %d", n, len(features), used)
+	}
+
+	beta, err := solve(xtx)
+	if err != nil {
+		return nil, err
+	}
+	m := &RegressionPowerModel{Intercept: beta[0], Coefficients: make(map[string]float64, len(features))}
+	for i, f := range features {
+		m.Coefficients[f] = beta[i+1]
+	}
+	return m, nil
+}
+
+// solve runs Gaussian elimination with partial pivoting on the augmented
+// matrix a.
+func solve(a [][]float64) ([]float64, error) {
+	n := len(a)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(a[r][col]) > math.Abs(a[pivot][col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return nil, errors.New("samples do not determine the model, features are constant or collinear")
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		for r := col + 1; r < n; r++ {
+			f := a[r][col] / a[col][col]
+			for c := col; c <= n; c++ {
+				a[r][c] -= f * a[col][c]
+			}
+		}
+	}
+	x := make([]float64, n)
+	for r := n - 1; r >= 0; r-- {
+		sum := a[r][n]
+		for c := r + 1; c < n; c++ {
+			sum -= a[r][c] * x[c]
+		}
+		x[r] = sum / a[r][r]
+	}
+	return x, nil
+}
+
+// SetPowerModel replaces the model used to fill LatestPredictedPower.
+func (w *ServerWorker) SetPowerModel(m PowerModel) {
+	w.modelMu.Lock()
+	w.powerModel = m
+	w.modelMu.Unlock()
+}
+
+// currentPowerModel returns the model set last, which calibration may
+// replace at any time.
+func (w *ServerWorker) currentPowerModel() PowerModel {
+	w.modelMu.RLock()
+	defer w.modelMu.RUnlock()
+	return w.powerModel
+}
+
+// initPowerModel picks the regression model from the config when there is
+// one, then a model saved by an earlier calibration, and a linear model
+// over DynamicRange otherwise.
+func (w *ServerWorker) initPowerModel() error {
+	if window := w.config.Calibration.Window; window > 0 {
+		if window < minCalibrationSamples {
+			return fmt.Errorf("calibration window %d is under the %d samples a fit needs", window, minCalibrationSamples)
+		}
+		w.calibrator = newCalibrator(w.config.Calibration)
+	}
+	if w.config.PowerModel != nil {
+		w.SetPowerModel(w.config.PowerModel)
+		return nil
+	}
+	if path := w.config.Calibration.Path; path != "" {
+		if m, err := LoadPowerModel(path); err == nil {
+			w.SetPowerModel(m)
+			return nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if len(w.DynamicRange) == 0 {
+		return nil
+	}
+	m, err := NewLinearPowerModel(w.DynamicRange)
+	if err != nil {
+		return err
+	}
+	w.SetPowerModel(m)
+	return nil
+}
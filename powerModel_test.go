@@ -0,0 +1,140 @@
+package worker
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFitRegressionPowerModel(t *testing.T) {
+	// watts = 40 + 0.8 cpu + 0.05 freq
+	var samples []PowerSample
+	for _, s := range []struct{ cpu, freq float64 }{
+		{0, 800}, {10, 1200}, {25, 2000}, {50, 1600}, {75, 3000}, {100, 2400},
+	} {
+		watts := 40 + 0.8*s.cpu + 0.05*s.freq
+		samples = append(samples, PowerSample{
+			Stats: map[string]interface{}{"cpupercent": s.cpu, "freq": s.freq},
+			Watts: float32(watts),
+		})
+	}
+
+	tests := []struct {
+		name     string
+		samples  []PowerSample
+		features []string
+		want     *RegressionPowerModel
+		wantErr  bool
+	}{
+		{
+			name:     "two features",
+			samples:  samples,
+			features: []string{"cpupercent", "freq"},
+			want:     &RegressionPowerModel{Intercept: 40, Coefficients: map[string]float64{"cpupercent": 0.8, "freq": 0.05}},
+		},
+		{
+			name:     "samples missing a feature are skipped",
+			samples:  append([]PowerSample{{Stats: map[string]interface{}{"cpupercent": 50.0}, Watts: 1000}}, samples...),
+			features: []string{"cpupercent", "freq"},
+			want:     &RegressionPowerModel{Intercept: 40, Coefficients: map[string]float64{"cpupercent": 0.8, "freq": 0.05}},
+		},
+		{
+			name:     "too few samples",
+			samples:  samples[:2],
+			features: []string{"cpupercent", "freq"},
+			wantErr:  true,
+		},
+		{
+			name: "constant feature",
+			samples: []PowerSample{
+				{Stats: map[string]interface{}{"cpupercent": 50.0}, Watts: 80},
+				{Stats: map[string]interface{}{"cpupercent": 50.0}, Watts: 90},
+			},
+			features: []string{"cpupercent"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FitRegressionPowerModel(tt.samples, tt.features)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FitRegressionPowerModel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !approxEqual(got.Intercept, tt.want.Intercept) {
+				t.Errorf("intercept = %g, want %g", got.Intercept, tt.want.Intercept)
+			}
+			for f, want := range tt.want.Coefficients {
+				if !approxEqual(got.Coefficients[f], want) {
+					t.Errorf("coefficient of %s = %g, want %g", f, got.Coefficients[f], want)
+				}
+			}
+		})
+	}
+}
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-3
+}
+
+func TestPairPowerSamples(t *testing.T) {
+	t0 := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	readings := []PowerReading{
+		{Time: t0.Add(2 * time.Second), Watts: 120},
+		{Time: t0, Watts: 100},
+		{Time: t0.Add(time.Second), Watts: 110},
+	}
+	tests := []struct {
+		name string
+		at   time.Duration
+		want []float32
+	}{
+		{"exact", time.Second, []float32{110}},
+		{"closest before", 1100 * time.Millisecond, []float32{110}},
+		{"closest after", 1900 * time.Millisecond, []float32{120}},
+		{"before all within tolerance", -200 * time.Millisecond, []float32{100}},
+		{"after all within tolerance", 2300 * time.Millisecond, []float32{120}},
+		{"out of tolerance", 3 * time.Second, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := []TimedStats{{Time: t0.Add(tt.at), Stats: map[string]interface{}{"cpupercent": 1.0}}}
+			samples := PairPowerSamples(readings, stats, 500*time.Millisecond)
+			if len(samples) != len(tt.want) {
+				t.Fatalf("PairPowerSamples() = %v, want watts %v", samples, tt.want)
+			}
+			for i, s := range samples {
+				if s.Watts != tt.want[i] {
+					t.Errorf("PairPowerSamples() watts = %g, want %g", s.Watts, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLinearPowerModel(t *testing.T) {
+	m, err := NewLinearPowerModel([]float32{50, 150})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		cpu  float64
+		want float32
+	}{
+		{0, 50}, {50, 100}, {100, 150}, {-10, 50}, {200, 150},
+	}
+	for _, tt := range tests {
+		got, err := m.Predict(map[string]interface{}{"cpupercent": tt.cpu})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("Predict(%g) = %g, want %g", tt.cpu, got, tt.want)
+		}
+	}
+	if _, err := NewLinearPowerModel([]float32{150, 50}); err == nil {
+		t.Error("NewLinearPowerModel() with max below idle, want an error")
+	}
+}
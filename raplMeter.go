@@ -0,0 +1,90 @@
+package worker
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultRAPLPath is where Linux exposes the RAPL energy counters.
+const defaultRAPLPath = "/sys/class/powercap"
+
+// raplZone is the energy counter of one CPU package.
+type raplZone struct {
+	energy   string
+	maxRange uint64
+	last     uint64
+}
+
+// raplReader turns the energy counters of every CPU package into watts,
+// averaged since the previous read.
+type raplReader struct {
+	zones []*raplZone
+	last  time.Time
+}
+
+// NewRAPLMeter reads the power of the machine's CPU packages from the
+// intel-rapl zones under root, /sys/class/powercap when empty. It only
+// covers the packages (cores, uncore and, where reported, DRAM), not the
+// whole machine.
+func NewRAPLMeter(root string, interval time.Duration) (*PollingMeter, error) {
+	if root == "" {
+		root = defaultRAPLPath
+	}
+	dirs, err := filepath.Glob(filepath.Join(root, "intel-rapl:*"))
+	if err != nil {
+		return nil, err
+	}
+	r := &raplReader{}
+	for _, dir := range dirs {
+		// intel-rapl:0:0 and the like are subzones of a package
+		if strings.Count(filepath.Base(dir), ":") != 1 {
+			continue
+		}
+		maxRange, err := readUint(filepath.Join(dir, "max_energy_range_uj"))
+		if err != nil {
+			return nil, err
+		}
+		r.zones = append(r.zones, &raplZone{energy: filepath.Join(dir, "energy_uj"), maxRange: maxRange})
+	}
+	if len(r.zones) == 0 {
+		return nil, errors.New("no RAPL zones under " + root)
+	}
+	return newPollingMeter(interval, r.read), nil
+}
+
+func (r *raplReader) read() (float32, bool, error) {
+	now := time.Now()
+	var joules float64
+	for _, z := range r.zones {
+		energy, err := readUint(z.energy)
+		if err != nil {
+			return 0, false, err
+		}
+		delta := energy - z.last
+		if energy < z.last {
+			// the counter wrapped around
+			delta = z.maxRange - z.last + energy
+		}
+		joules += float64(delta) / 1e6
+		z.last = energy
+	}
+	first := r.last.IsZero()
+	elapsed := now.Sub(r.last).Seconds()
+	r.last = now
+	if first || elapsed <= 0 {
+		return 0, false, nil
+	}
+	return float32(joules / elapsed), true, nil
+}
+
+func readUint(path string) (uint64, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(buf)), 10, 64)
+}
@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRAPLReaderRead(t *testing.T) {
+	tests := []struct {
+		name        string
+		first, next uint64
+		// joules read between first and next
+		want float64
+	}{
+		{"increasing", 1000000, 3000000, 2},
+		{"wrapped around", 9000000, 1000000, 2},
+		{"unchanged", 5000000, 5000000, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			energy := filepath.Join(t.TempDir(), "energy_uj")
+			write := func(uj uint64) {
+				if err := os.WriteFile(energy, []byte(strconv.FormatUint(uj, 10)+"\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			r := &raplReader{zones: []*raplZone{{energy: energy, maxRange: 10000000}}}
+
+			write(tt.first)
+			if _, ok, err := r.read(); err != nil || ok {
+				t.Fatalf("first read() = %v, %v, want no reading", ok, err)
+			}
+			// pretend a second went by since the first read
+			r.last = r.last.Add(-time.Second)
+			write(tt.next)
+			watts, ok, err := r.read()
+			if err != nil || !ok {
+				t.Fatalf("read() = %v, %v, want a reading", ok, err)
+			}
+			// the second is stretched by the time spent reading
+			if got := float64(watts); got > tt.want || got < tt.want*0.9 {
+				t.Errorf("read() = %g W, want about %g W", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRAPLMeter(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"intel-rapl:0", "intel-rapl:0:0", "intel-rapl:1"} {
+		path := filepath.Join(root, dir)
+		if err := os.Mkdir(path, 0755); err != nil {
+			t.Fatal(err)
+		}
+		for file, value := range map[string]string{"energy_uj": "1000", "max_energy_range_uj": "262143328850"} {
+			if err := os.WriteFile(filepath.Join(path, file), []byte(value), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if _, err := NewRAPLMeter(root, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewRAPLMeter(t.TempDir(), time.Second); err == nil {
+		t.Error("NewRAPLMeter() without zones, want an error")
+	}
+}
@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// defaultReconcileInterval is how often RunReconciler syncs with docker when
+// the config sets no reconcileInterval.
+const defaultReconcileInterval = 10 * time.Second
+
+// Reconcile syncs RunningJobs with docker, killing jobs past their duration
+// along with orphan containers, and samples the energy of the jobs left.
+func (w *ServerWorker) Reconcile(ctx context.Context) error {
+	if _, err := w.syncJobs(ctx); err != nil {
+		w.refreshState(ctx)
+		return err
+	}
+	for _, id := range w.jobIDs(&w.RunningJobs) {
+		w.sampleEnergy(ctx, id)
+	}
+	w.refreshState(ctx)
+	return nil
+}
+
+// RunReconciler calls Reconcile every reconcileInterval until ctx is done,
+// so durations are enforced whether or not a manager is polling.
+func (w *ServerWorker) RunReconciler(ctx context.Context) error {
+	interval := defaultReconcileInterval
+	if w.config.ReconcileInterval > 0 {
+		interval = time.Duration(w.config.ReconcileInterval * float64(time.Second))
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := w.Reconcile(ctx); err != nil {
+				log.Print(err)
+			}
+		}
+	}
+}
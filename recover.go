@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	job "github.com/Nguyen-Hoa/job"
+	"github.com/docker/docker/api/types"
+)
+
+// recoverJobs rebuilds RunningJobs from the labels and inspect data of the
+// containers the worker started before it restarted, so their original
+// deadlines are still enforced. Containers without a duration label are
+// left to the orphan policy.
+func (w *ServerWorker) recoverJobs(ctx context.Context, containers []types.Container) {
+	for _, container := range containers {
+		if j, ok := w.recoverJob(ctx, container); ok {
+			log.Printf("recovered job %s, started %s, duration %s", container.ID, j.StartTime, j.Duration)
+			w.setJob(&w.RunningJobs, container.ID, j)
+			w.history.startedContainer(j)
+			w.watchRunningJob(container.ID)
+		}
+	}
+}
+
+func (w *ServerWorker) recoverJob(ctx context.Context, container types.Container) (job.DockerJob, bool) {
+	seconds, err := strconv.Atoi(container.Labels[LabelDuration])
+	if err != nil {
+		return job.DockerJob{}, false
+	}
+
+	// StartJob starts a container right after submitting it, so the submit
+	// time stands in when docker has no start time
+	start := time.Unix(container.Created, 0)
+	if t, err := time.Parse(time.RFC3339Nano, container.Labels[LabelSubmitTime]); err == nil {
+		start = t
+	}
+	if info, err := w._docker.ContainerInspect(ctx, container.ID); err != nil {
+		log.Print(err)
+	} else if info.ContainerJSONBase != nil && info.State != nil {
+		if t, err := time.Parse(time.RFC3339Nano, info.State.StartedAt); err == nil && !t.IsZero() {
+			start = t
+		}
+	}
+
+	// like StartJob, TotalRunTime starts at 0 and updateRunningJobs adds the
+	// time since start on every pass
+	return job.DockerJob{
+		BaseJob: job.BaseJob{
+			StartTime: start,
+			Duration:  time.Duration(seconds) * time.Second,
+		},
+		Container: container,
+	}, true
+}
@@ -0,0 +1,149 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// Docker refuses limits under these.
+const (
+	minJobCPUs   = 0.01
+	minJobMemory = 6 << 20
+)
+
+// reservation is what a job holds of the worker's cores and memory. It is
+// kept in the job's labels, so it survives a restart.
+type reservation struct {
+	CPUs   float64
+	Memory int64
+}
+
+func (r reservation) labels() map[string]string {
+	labels := make(map[string]string)
+	if r.CPUs > 0 {
+		labels[LabelCPUs] = strconv.FormatFloat(r.CPUs, 'f', -1, 64)
+	}
+	if r.Memory > 0 {
+		labels[LabelMemory] = strconv.FormatInt(r.Memory, 10)
+	}
+	return labels
+}
+
+func reservationOf(labels map[string]string) reservation {
+	var r reservation
+	if v, ok := labels[LabelCPUs]; ok {
+		r.CPUs, _ = strconv.ParseFloat(v, 64)
+	}
+	if v, ok := labels[LabelMemory]; ok {
+		r.Memory, _ = strconv.ParseInt(v, 10, 64)
+	}
+	return r
+}
+
+// reserved sums the reservations of the running jobs.
+func (w *ServerWorker) reserved() reservation {
+	var total reservation
+	for _, j := range w.runningJobs() {
+		r := reservationOf(j.Labels)
+		total.CPUs += r.CPUs
+		total.Memory += r.Memory
+	}
+	return total
+}
+
+// checkResources compares the limits requested by j with what is left of
+// the worker's Cores and MemThresh (a percentage of the machine's memory)
+// once the running jobs' reservations are taken out. Requests over what is
+// left are rejected with ErrResourceLimit, or clamped when the worker is
+// configured with clampResources. Jobs requesting no limit are limited to
+// what is left without reserving it, so they cannot starve the jobs that
+// did. Callers hold syncMu until the job is in RunningJobs.
+func (w *ServerWorker) checkResources(ctx context.Context, j *JobSpec) (reservation, error) {
+	cores := w.Cores
+	if cores <= 0 {
+		cores = w.ncpu
+	}
+	used := w.reserved()
+	res := reservation{CPUs: j.CPUs, Memory: j.Memory}
+	if cores > 0 {
+		free := float64(cores) - used.CPUs
+		switch {
+		case free < minJobCPUs:
+			return reservation{}, fmt.Errorf("%w: %.2f of %d cores reserved", ErrResourceLimit, used.CPUs, cores)
+		case j.CPUs == 0:
+			j.CPUs = free
+		case j.CPUs > free:
+			if !w.config.ClampResources {
+				return reservation{}, fmt.Errorf("%w: %.2f cpus requested, %.2f of %d cores free", ErrResourceLimit, j.CPUs, free, cores)
+			}
+			log.Printf("clamping cpus from %.2f to %.2f", j.CPUs, free)
+			j.CPUs = free
+			res.CPUs = free
+		}
+	}
+
+	if j.CpusetCpus != "" && cores > 0 {
+		cpus, _ := parseCpuset(j.CpusetCpus)
+		for _, cpu := range cpus {
+			if cpu >= cores {
+				return reservation{}, fmt.Errorf("%w: cpuset %q uses cpu %d, worker has %d cores", ErrResourceLimit, j.CpusetCpus, cpu, cores)
+			}
+		}
+	}
+
+	if maxMem := w.maxJobMemory(); maxMem > 0 {
+		free := maxMem - used.Memory
+		switch {
+		case free < minJobMemory:
+			return reservation{}, fmt.Errorf("%w: %d of %d bytes of memory reserved", ErrResourceLimit, used.Memory, maxMem)
+		case j.Memory == 0:
+			j.Memory = free
+		case j.Memory > free:
+			if !w.config.ClampResources {
+				return reservation{}, fmt.Errorf("%w: %d bytes of memory requested, %d of %d free", ErrResourceLimit, j.Memory, free, maxMem)
+			}
+			log.Printf("clamping memory from %d to %d bytes", j.Memory, free)
+			j.Memory = free
+			res.Memory = free
+		}
+	}
+	return res, nil
+}
+
+// maxJobMemory is the most memory the jobs may reserve together, or 0 when
+// the machine's memory is unknown.
+func (w *ServerWorker) maxJobMemory() int64 {
+	if w.memTotal <= 0 {
+		return 0
+	}
+	if w.MemThresh <= 0 || w.MemThresh >= 100 {
+		return w.memTotal
+	}
+	return int64(float64(w.memTotal) * float64(w.MemThresh) / 100)
+}
+
+// parseCpuset expands docker's cpuset notation, e.g. "0-3,5", into the list
+// of cpus it names.
+func parseCpuset(cpuset string) ([]int, error) {
+	var cpus []int
+	for _, part := range strings.Split(cpuset, ",") {
+		bounds := strings.SplitN(part, "-", 2)
+		lo, err := strconv.Atoi(bounds[0])
+		if err != nil || lo < 0 {
+			return nil, fmt.Errorf("invalid cpuset %q", cpuset)
+		}
+		hi := lo
+		if len(bounds) == 2 {
+			if hi, err = strconv.Atoi(bounds[1]); err != nil || hi < lo {
+				return nil, fmt.Errorf("invalid cpuset %q", cpuset)
+			}
+		}
+		for cpu := lo; cpu <= hi; cpu++ {
+			cpus = append(cpus, cpu)
+		}
+	}
+	return cpus, nil
+}
@@ -0,0 +1,127 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	job "github.com/Nguyen-Hoa/job"
+	"github.com/docker/docker/api/types"
+)
+
+func TestParseCpuset(t *testing.T) {
+	tests := []struct {
+		cpuset  string
+		want    []int
+		wantErr bool
+	}{
+		{"0", []int{0}, false},
+		{"0-3", []int{0, 1, 2, 3}, false},
+		{"0-1,5", []int{0, 1, 5}, false},
+		{"2,4-5", []int{2, 4, 5}, false},
+		{"1-1", []int{1}, false},
+		{"", nil, true},
+		{"a", nil, true},
+		{"-1", nil, true},
+		{"3-1", nil, true},
+		{"0-", nil, true},
+		{"0,,1", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cpuset, func(t *testing.T) {
+			got, err := parseCpuset(tt.cpuset)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCpuset() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCpuset() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckResources(t *testing.T) {
+	const gb = 1 << 30
+	tests := []struct {
+		name     string
+		clamp    bool
+		reserved reservation
+		spec     JobSpec
+		want     reservation
+		wantSpec JobSpec
+		wantErr  error
+	}{
+		{
+			name:     "within limits",
+			spec:     JobSpec{CPUs: 1, Memory: gb},
+			want:     reservation{CPUs: 1, Memory: gb},
+			wantSpec: JobSpec{CPUs: 1, Memory: gb},
+		},
+		{
+			name:     "no limits takes what is left without reserving it",
+			reserved: reservation{CPUs: 1, Memory: gb},
+			want:     reservation{},
+			wantSpec: JobSpec{CPUs: 3, Memory: 3 * gb},
+		},
+		{
+			name:     "over free cpus",
+			reserved: reservation{CPUs: 3},
+			spec:     JobSpec{CPUs: 2},
+			wantErr:  ErrResourceLimit,
+		},
+		{
+			name:     "over free memory",
+			reserved: reservation{Memory: 3 * gb},
+			spec:     JobSpec{Memory: 2 * gb},
+			wantErr:  ErrResourceLimit,
+		},
+		{
+			name:     "clamped",
+			clamp:    true,
+			reserved: reservation{CPUs: 3, Memory: 3 * gb},
+			spec:     JobSpec{CPUs: 2, Memory: 2 * gb},
+			want:     reservation{CPUs: 1, Memory: gb},
+			wantSpec: JobSpec{CPUs: 1, Memory: gb},
+		},
+		{
+			name:     "all cpus reserved",
+			reserved: reservation{CPUs: 4},
+			spec:     JobSpec{CPUs: 1},
+			wantErr:  ErrResourceLimit,
+		},
+		{
+			name:    "cpuset past cores",
+			spec:    JobSpec{CPUs: 1, CpusetCpus: "2-4"},
+			wantErr: ErrResourceLimit,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &ServerWorker{}
+			w.Cores = 4
+			w.MemThresh = 50
+			w.memTotal = 8 * gb
+			w.config.ClampResources = tt.clamp
+			w.RunningJobs.Init()
+			w.setJob(&w.RunningJobs, "running", job.DockerJob{
+				Container: types.Container{ID: "running", Labels: tt.reserved.labels()},
+			})
+
+			spec := tt.spec
+			got, err := w.checkResources(context.Background(), &spec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("checkResources() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("checkResources() = %+v, want %+v", got, tt.want)
+			}
+			if spec.CPUs != tt.wantSpec.CPUs || spec.Memory != tt.wantSpec.Memory {
+				t.Errorf("checkResources() limited the job to %g cpus and %d bytes, want %g and %d", spec.CPUs, spec.Memory, tt.wantSpec.CPUs, tt.wantSpec.Memory)
+			}
+		})
+	}
+}
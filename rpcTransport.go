@@ -0,0 +1,171 @@
+package worker
+
+import (
+	"context"
+	"net/rpc"
+
+	job "github.com/Nguyen-Hoa/job"
+)
+
+// RPCTransport talks to a worker registered with RPCServerWorker.Register.
+type RPCTransport struct {
+	client *rpc.Client
+}
+
+func NewRPCTransport(client *rpc.Client) *RPCTransport {
+	return &RPCTransport{client: client}
+}
+
+// DialRPC connects to a worker serving net/rpc over HTTP at address.
+func DialRPC(address string) (*RPCTransport, error) {
+	client, err := rpc.DialHTTP("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	return NewRPCTransport(client), nil
+}
+
+// call invokes an RPCServerWorker method, giving up once ctx is done.
+func (t *RPCTransport) call(ctx context.Context, method string, args interface{}, reply interface{}) error {
+	call := t.client.Go("RPCServerWorker."+method, args, reply, make(chan *rpc.Call, 1))
+	select {
+	case <-call.Done:
+		if err, ok := call.Error.(rpc.ServerError); ok {
+			return remoteError(string(err))
+		}
+		return call.Error
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *RPCTransport) StartJob(ctx context.Context, j JobSpec) (string, error) {
+	var reply string
+	if err := t.call(ctx, "StartJob", j, &reply); err != nil {
+		return "", err
+	}
+	return reply, nil
+}
+
+func (t *RPCTransport) StopJob(ctx context.Context, ID string) (JobResult, error) {
+	var reply JobResult
+	if err := t.call(ctx, "StopJob", ID, &reply); err != nil {
+		return JobResult{}, err
+	}
+	return reply, nil
+}
+
+func (t *RPCTransport) GetRunningJobs(ctx context.Context) (map[string]job.DockerJob, error) {
+	var reply map[string]job.DockerJob
+	if err := t.call(ctx, "GetRunningJobs", "", &reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (t *RPCTransport) GetRunningJobsStats(ctx context.Context) (map[string][]byte, error) {
+	var reply map[string][]byte
+	if err := t.call(ctx, "GetRunningJobsStats", "", &reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (t *RPCTransport) Poll(ctx context.Context) (map[string]interface{}, error) {
+	var reply map[string]interface{}
+	if err := t.call(ctx, "Poll", "", &reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (t *RPCTransport) ReducedStats(ctx context.Context) (map[string]interface{}, error) {
+	var reply map[string]interface{}
+	if err := t.call(ctx, "ReducedStats", "", &reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (t *RPCTransport) StartMeter(ctx context.Context) error {
+	var reply string
+	return t.call(ctx, "StartMeter", "", &reply)
+}
+
+func (t *RPCTransport) StopMeter(ctx context.Context) (string, error) {
+	var reply string
+	if err := t.call(ctx, "StopMeter", "", &reply); err != nil {
+		return "", err
+	}
+	return reply, nil
+}
+
+func (t *RPCTransport) GetState(ctx context.Context) (State, error) {
+	var reply State
+	if err := t.call(ctx, "GetState", "", &reply); err != nil {
+		return "", err
+	}
+	return reply, nil
+}
+
+func (t *RPCTransport) Drain(ctx context.Context) error {
+	var reply State
+	return t.call(ctx, "Drain", "", &reply)
+}
+
+func (t *RPCTransport) Undrain(ctx context.Context) error {
+	var reply State
+	return t.call(ctx, "Undrain", "", &reply)
+}
+
+func (t *RPCTransport) GetPowerModel(ctx context.Context) (*RegressionPowerModel, error) {
+	reply := &RegressionPowerModel{}
+	if err := t.call(ctx, "GetPowerModel", "", reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (t *RPCTransport) MeterLog(ctx context.Context, q MeterLogQuery) ([]PowerReading, error) {
+	var reply []PowerReading
+	if err := t.call(ctx, "MeterLog", q, &reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (t *RPCTransport) PowerCapActions(ctx context.Context) ([]PowerCapAction, error) {
+	var reply []PowerCapAction
+	if err := t.call(ctx, "PowerCapActions", "", &reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (t *RPCTransport) ListJobHistory(ctx context.Context, q HistoryQuery) ([]JobRecord, error) {
+	var reply []JobRecord
+	if err := t.call(ctx, "ListJobHistory", q, &reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (t *RPCTransport) JobStatus(ctx context.Context, ID string) (JobStatus, error) {
+	var reply JobStatus
+	if err := t.call(ctx, "JobStatus", ID, &reply); err != nil {
+		return JobStatus{}, err
+	}
+	return reply, nil
+}
+
+func (t *RPCTransport) PowerMeterOn(ctx context.Context) (bool, error) {
+	var reply bool
+	if err := t.call(ctx, "PowerMeterOn", "", &reply); err != nil {
+		return false, err
+	}
+	return reply, nil
+}
+
+func (t *RPCTransport) Close() error {
+	return t.client.Close()
+}
@@ -0,0 +1,36 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/rpc"
+	"testing"
+)
+
+func newTestRPCTransport(t *testing.T) *RPCTransport {
+	w := &RPCServerWorker{}
+	initTestWorker(t, &w.ServerWorker)
+	server := rpc.NewServer()
+	if err := w.Register(server); err != nil {
+		t.Fatal(err)
+	}
+	clientConn, serverConn := net.Pipe()
+	go server.ServeConn(serverConn)
+	tr := NewRPCTransport(rpc.NewClient(clientConn))
+	t.Cleanup(func() { tr.Close() })
+	return tr
+}
+
+func TestRPCTransport(t *testing.T) {
+	testTransport(t, newTestRPCTransport(t))
+}
+
+func TestRPCTransportCanceled(t *testing.T) {
+	tr := newTestRPCTransport(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := tr.JobStatus(ctx, "running"); !errors.Is(err, context.Canceled) {
+		t.Errorf("JobStatus() error = %v, want %v", err, context.Canceled)
+	}
+}
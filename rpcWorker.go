@@ -2,306 +2,181 @@ package worker
 
 import (
 	"context"
-	"errors"
-	"io"
 	"log"
-	"os"
-	"time"
+	"net/rpc"
 
 	job "github.com/Nguyen-Hoa/job"
-	profile "github.com/Nguyen-Hoa/profile"
-	powerMeter "github.com/Nguyen-Hoa/wattsup"
-
-	"github.com/docker/docker/api/types"
-	"github.com/docker/docker/api/types/container"
-	"github.com/docker/docker/client"
 )
 
-func (w *RPCServerWorker) Init(config WorkerConfig) error {
-	w.config = config
-
-	// Intialize Variables
-	w.Name = config.Name
-	w.Address = config.Address
-	w.Hostname, _ = os.Hostname()
-	w.CpuThresh = config.CpuThresh
-	w.MemThresh = config.MemThresh
-	w.PowerThresh = config.PowerThresh
-	w.Cores = config.Cores
-	w.DynamicRange = config.DynamicRange
-	w.RPCServer = config.RPCServer
-	w.RPCPort = config.RPCPort
-	w.HTTPPort = config.HTTPPort
-
-	w.Available = false
-	w.LatestActualPower = 0
-	w.LatestPredictedPower = 0
-	w.LatestCPU = 0
-	w.LatestMem = 0
-
-	w.RunningJobStats = make(map[string]interface{})
-	w.RunningJobs = job.SharedDockerJobsMap{}
-	w.jobsToKill = job.SharedDockerJobsMap{}
-	w.RunningJobs.Init()
-	w.jobsToKill.Init()
-
-	if config.Wattsup.Path == "" {
-		w.HasPowerMeter = false
-	} else {
-		w.HasPowerMeter = true
-		// Initialize Power Meter
-		w._powerMeter = powerMeter.New(config.Wattsup)
-	}
-
-	// Initialize Docker API
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
-	if err != nil {
-		return err
-	}
-	w._docker = cli
-	containers, err := cli.ContainerList(context.Background(), types.ContainerListOptions{})
-	if err != nil {
-		return err
-	}
-	w.updateRunningJobs(containers)
+// Register publishes the worker on server under the "RPCServerWorker"
+// service name that ManagerWorker calls.
+func (w *RPCServerWorker) Register(server *rpc.Server) error {
+	return server.RegisterName("RPCServerWorker", &rpcHandler{w: w})
+}
 
-	return nil
+// rpcHandler holds the net/rpc shaped methods of RPCServerWorker, which
+// otherwise clash with the Worker interface.
+type rpcHandler struct {
+	w *RPCServerWorker
 }
 
-func (w *RPCServerWorker) GetMeterPath(_ string, reply *string) error {
-	*reply = w._powerMeter.Fullpath
+func (h *rpcHandler) GetMeterPath(_ string, reply *string) error {
+	*reply = h.w.GetMeterPath()
 	return nil
 }
 
-func (w *RPCServerWorker) StartMeter(_ string, reply *string) error {
-	if w._powerMeter.Running() {
+func (h *rpcHandler) StartMeter(_ string, reply *string) error {
+	if h.w.HasPowerMeter && h.w.meter.Running() {
 		*reply = "meter was already running, restarting meter"
-		if err := w._powerMeter.Stop(); err != nil {
-			*reply = err.Error()
-			return err
-		} else {
-			w._powerMeter = powerMeter.New(w.config.Wattsup)
-		}
 	}
-
-	if err := w._powerMeter.Start(); err != nil {
+	if err := h.w.StartMeter(context.Background()); err != nil {
 		*reply = err.Error()
 		return err
-	} else {
-		return nil
 	}
+	return nil
 }
 
-func (w *RPCServerWorker) StopMeter(_ string, reply *string) error {
-	if err := w._powerMeter.Stop(); err != nil {
+func (h *rpcHandler) StopMeter(_ string, reply *string) error {
+	path, err := h.w.StopMeter(context.Background())
+	if err != nil {
 		*reply = err.Error()
 		return err
-	} else {
-		w._powerMeter = powerMeter.New(w.config.Wattsup)
-		return nil
 	}
+	*reply = path
+	return nil
 }
 
-func (w *RPCServerWorker) verifyImage(ID string) bool {
-	if _, _, err := w._docker.ImageInspectWithRaw(context.Background(), ID); err != nil {
-		log.Println(err)
-		log.Println("Attempting to pull image...")
-		if _, err := w._docker.ImagePull(context.Background(), ID, types.ImagePullOptions{}); err != nil {
-			log.Println(err)
-			log.Println("Failed to pull image...")
-		}
+func (h *rpcHandler) MeterLog(q MeterLogQuery, reply *[]PowerReading) error {
+	readings, err := h.w.MeterLog(context.Background(), q)
+	if err != nil {
+		return err
 	}
-	return true
+	*reply = readings
+	return nil
 }
 
-func (w *RPCServerWorker) verifyContainer(ID string) bool {
-	if _, exists := w.RunningJobs.Get(ID); exists {
-		return true
+func (h *rpcHandler) StartJob(j JobSpec, reply *string) error {
+	id, err := h.w.StartJob(context.Background(), j)
+	if err != nil {
+		*reply = err.Error()
+		return err
 	}
-	return false
+	*reply = id
+	return nil
 }
 
-func (w *RPCServerWorker) StartJob(j job.Job, reply *string) error {
-	// verify image exists
-	if !w.verifyImage(j.Image) {
-		*reply = "image does not exist"
-		return errors.New("image does not exist")
-	}
-
-	// create image
-	resp, err := w._docker.ContainerCreate(context.Background(),
-		&container.Config{
-			Image: j.Image,
-			Cmd:   j.Cmd,
-		},
-		&container.HostConfig{
-			AutoRemove: true,
-		},
-		nil,
-		nil,
-		"",
-	)
+func (h *rpcHandler) StopJob(ID string, reply *JobResult) error {
+	res, err := h.w.StopJob(context.Background(), ID)
 	if err != nil {
-		*reply = err.Error()
 		return err
 	}
+	*reply = res
+	return nil
+}
 
-	// start image
-	if err := w._docker.ContainerStart(context.Background(), resp.ID, types.ContainerStartOptions{}); err != nil {
-		*reply = err.Error()
+func (h *rpcHandler) GetRunningJobs(_ string, reply *map[string]job.DockerJob) error {
+	jobs, err := h.w.GetRunningJobs(context.Background())
+	if err != nil {
 		return err
 	}
-	// update list of running jobs
-	newCtr := job.DockerJob{
-		BaseJob: job.BaseJob{
-			StartTime:    time.Now(),
-			TotalRunTime: time.Duration(0),
-			Duration:     time.Duration(j.Duration) * time.Second,
-		},
-		Container: types.Container{ID: resp.ID},
-	}
-	w.RunningJobs.Update(resp.ID, newCtr)
-	*reply = resp.ID
-
+	*reply = jobs
 	return nil
 }
 
-func (w *RPCServerWorker) stopJob(ID string) error {
-	if w.verifyContainer(ID) {
-		timeout := time.Duration(-1)
-		if err := w._docker.ContainerStop(context.Background(), ID, &timeout); err != nil {
-			return err
-		}
-	} else {
-		return errors.New("failed to stop: Job ID not found")
+func (h *rpcHandler) GetRunningJobsStats(_ string, reply *map[string][]byte) error {
+	stats, err := h.w.GetRunningJobsStats(context.Background())
+	if err != nil {
+		return err
 	}
-	ctr, _ := w.RunningJobs.Get(ID)
-	ctr.UpdateTotalRunTime(time.Now())
-	log.Printf("Stopped %s, total run time: %s", ID, ctr.TotalRunTime)
+	*reply = stats
 	return nil
 }
 
-func (w *RPCServerWorker) updateRunningJobs(containers []types.Container) (job.SharedDockerJobsMap, error) {
-	ids := make([]string, 0)
-	for _, container := range containers {
-		if container.ID[:12] != w.Hostname {
-			if w.verifyContainer(container.ID) {
-				base, _ := w.RunningJobs.Get(container.ID)
-				updatedCtr := job.DockerJob{
-					BaseJob:   base.BaseJob,
-					Container: container,
-				}
-				updatedCtr.UpdateTotalRunTime(time.Now())
-				if updatedCtr.TotalRunTime >= updatedCtr.Duration {
-					w.jobsToKill.Update(updatedCtr.ID, updatedCtr)
-				}
-			} else {
-				log.Println("Found an orphan job")
-				newCtr := job.DockerJob{
-					BaseJob: job.BaseJob{
-						StartTime:    time.Now(),
-						TotalRunTime: time.Duration(0),
-						Duration:     time.Duration(-1),
-					},
-					Container: types.Container{ID: container.ID},
-				}
-				w.jobsToKill.Update(newCtr.ID, newCtr)
-				w.RunningJobs.Update(container.ID, newCtr)
-			}
-			ids = append(ids, container.ID)
-		}
+func (h *rpcHandler) Poll(_ string, reply *map[string]interface{}) error {
+	if res, err := h.w.Stats(context.Background()); err == nil {
+		*reply = res
+	} else {
+		log.Print(err)
+		return err
 	}
-	w.killJobs()
-	w.RunningJobs.Refresh(ids)
-	return w.RunningJobs, nil
+	return nil
 }
 
-func (w *RPCServerWorker) GetRunningJobs(_ string, reply *map[string]job.DockerJob) error {
-	containers, err := w.getRunningJobs()
-	if err != nil {
+func (h *rpcHandler) ReducedStats(_ string, reply *map[string]interface{}) error {
+	if stats, err := h.w.ReducedStats(context.Background()); err == nil {
+		*reply = stats
+	} else {
+		log.Print(err)
 		return err
 	}
-
-	RunningJobs, _ := w.updateRunningJobs(containers)
-	*reply = RunningJobs.Snap()
 	return nil
 }
 
-func (w *RPCServerWorker) getRunningJobs() ([]types.Container, error) {
-	containers, err := w._docker.ContainerList(context.Background(), types.ContainerListOptions{})
+func (h *rpcHandler) IsAvailable(_ string, reply *bool) error {
+	available, err := h.w.IsAvailable(context.Background())
 	if err != nil {
-		return nil, err
+		return err
 	}
-	w.updateRunningJobs(containers)
-	return containers, nil
+	*reply = available
+	return nil
 }
 
-func (w *RPCServerWorker) GetRunningJobsStats(_ string, reply *map[string][]byte) error {
-	containers, err := w._docker.ContainerList(context.Background(), types.ContainerListOptions{})
+func (h *rpcHandler) GetState(_ string, reply *State) error {
+	state, err := h.w.GetState(context.Background())
 	if err != nil {
 		return err
 	}
-	w.updateRunningJobs(containers)
+	*reply = state
+	return nil
+}
 
-	var containerStats map[string][]byte = make(map[string][]byte)
-	for _, container := range containers {
-		if container.ID[:12] != w.Hostname {
-			stats, err := w._docker.ContainerStatsOneShot(context.Background(), container.ID)
-			if err != nil {
-				log.Println("Failed to get stats for {}", container.ID)
-			}
-			defer stats.Body.Close()
-			raw_stats, err := io.ReadAll(stats.Body)
-			if err != nil {
-				log.Print(err)
-			}
-			containerStats[container.ID] = raw_stats
-		}
-	}
+func (h *rpcHandler) Drain(_ string, reply *State) error {
+	*reply = h.w.setDraining(context.Background(), true)
+	return nil
+}
 
-	*reply = containerStats
+func (h *rpcHandler) Undrain(_ string, reply *State) error {
+	*reply = h.w.setDraining(context.Background(), false)
 	return nil
 }
 
-func (w *RPCServerWorker) Poll(_ string, reply *map[string]interface{}) error {
-	if res, err := profile.Get11Stats(); err == nil {
-		*reply = res
-	} else {
-		log.Print(err)
+func (h *rpcHandler) GetPowerModel(_ string, reply *RegressionPowerModel) error {
+	m, err := h.w.GetPowerModel(context.Background())
+	if err != nil {
 		return err
 	}
-
+	*reply = *m
 	return nil
 }
 
-func (w *RPCServerWorker) ReducedStats(_ string, reply *map[string]interface{}) error {
-	if stats, err := profile.GetCPUAndMemStats(); err == nil {
-		*reply = stats
-	} else {
-		log.Print(err)
+func (h *rpcHandler) PowerCapActions(_ string, reply *[]PowerCapAction) error {
+	actions, err := h.w.PowerCapActions(context.Background())
+	if err != nil {
 		return err
 	}
+	*reply = actions
 	return nil
 }
 
-func (w *RPCServerWorker) IsAvailable(_ string, reply *bool) error {
-	*reply = w.Available
+func (h *rpcHandler) ListJobHistory(q HistoryQuery, reply *[]JobRecord) error {
+	records, err := h.w.ListJobHistory(context.Background(), q)
+	if err != nil {
+		return err
+	}
+	*reply = records
 	return nil
 }
 
-func (w *RPCServerWorker) PowerMeterOn(_ string, reply *bool) error {
-	*reply = w.HasPowerMeter
+func (h *rpcHandler) JobStatus(ID string, reply *JobStatus) error {
+	status, err := h.w.JobStatus(context.Background(), ID)
+	if err != nil {
+		return err
+	}
+	*reply = status
 	return nil
 }
 
-func (w *RPCServerWorker) killJobs() error {
-	for _, id := range w.jobsToKill.Keys() {
-		if err := w.stopJob(id); err != nil {
-			log.Print(err)
-		} else {
-			w.jobsToKill.Delete(id)
-			w.RunningJobs.Delete(id)
-		}
-	}
+func (h *rpcHandler) PowerMeterOn(_ string, reply *bool) error {
+	*reply = h.w.PowerMeterOn()
 	return nil
 }
@@ -3,19 +3,21 @@ package worker
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	job "github.com/Nguyen-Hoa/job"
 	profile "github.com/Nguyen-Hoa/profile"
-	powerMeter "github.com/Nguyen-Hoa/wattsup"
 	"github.com/docker/docker/api/types"
-	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
 )
 
+var errNoPowerMeter = errors.New("worker has no power meter")
+
 func (w *ServerWorker) Init(config WorkerConfig) error {
 	w.config = config
 
@@ -31,8 +33,10 @@ func (w *ServerWorker) Init(config WorkerConfig) error {
 	w.RPCServer = config.RPCServer
 	w.RPCPort = config.RPCPort
 	w.HTTPPort = config.HTTPPort
+	w.GRPCServer = config.GRPCServer
+	w.GRPCPort = config.GRPCPort
 
-	w.Available = false
+	w.setState(StateStarting)
 	w.LatestActualPower = 0
 	w.LatestPredictedPower = 0
 	w.LatestCPU = 0
@@ -43,13 +47,20 @@ func (w *ServerWorker) Init(config WorkerConfig) error {
 	w.jobsToKill = job.SharedDockerJobsMap{}
 	w.RunningJobs.Init()
 	w.jobsToKill.Init()
+	w.energy.init()
+	w.history.init(config.HistoryPath)
 
-	if config.Wattsup.Path == "" {
-		w.HasPowerMeter = false
-	} else {
-		w.HasPowerMeter = true
-		// Initialize Power Meter
-		w._powerMeter = powerMeter.New(config.Wattsup)
+	meter, err := newPowerMeter(config)
+	if err != nil {
+		return err
+	}
+	w.meter = meter
+	w.HasPowerMeter = meter != nil
+	if err := w.config.Orphans.validate(); err != nil {
+		return err
+	}
+	if err := w.initPowerModel(); err != nil {
+		return err
 	}
 
 	// Initialize Docker API
@@ -58,48 +69,78 @@ func (w *ServerWorker) Init(config WorkerConfig) error {
 		return err
 	}
 	w._docker = cli
-	containers, err := cli.ContainerList(context.Background(), types.ContainerListOptions{})
+	info, err := cli.Info(context.Background())
 	if err != nil {
+		w.setState(StateUnavailable)
 		return err
 	}
-	w.updateGetRunningJobs(containers)
+	w.ncpu = info.NCPU
+	w.memTotal = info.MemTotal
+
+	containers, err := w.listContainers(context.Background())
+	if err != nil {
+		w.setState(StateUnavailable)
+		return err
+	}
+	w.recoverJobs(context.Background(), containers)
+	w.updateRunningJobs(containers)
+	w.killJobs(context.Background())
+	w.refreshState(context.Background())
 
 	return nil
 }
 
+// GetMeterPath returns the log of the power meter, which is empty for
+// meters that keep no log.
 func (w *ServerWorker) GetMeterPath() string {
-	return w._powerMeter.Fullpath
+	if m, ok := w.meter.(LoggingPowerMeter); ok {
+		return m.Path()
+	}
+	return ""
 }
 
-func (w *ServerWorker) StartMeter() error {
-	if w._powerMeter.Running() {
-		if err := w._powerMeter.Stop(); err != nil {
+func (w *ServerWorker) StartMeter(ctx context.Context) error {
+	if !w.HasPowerMeter {
+		return errNoPowerMeter
+	}
+	if w.meter.Running() {
+		if err := w.meter.Stop(); err != nil {
+			w.meterErr = err
 			return err
-		} else {
-			w._powerMeter = powerMeter.New(w.config.Wattsup)
 		}
 	}
-	if err := w._powerMeter.Start(); err != nil {
+	if err := w.meter.Start(); err != nil {
+		w.meterErr = err
 		return err
-	} else {
-		return nil
 	}
+	w.meterEnergy.reset()
+	w.meterErr = nil
+	return nil
 }
 
-func (w *ServerWorker) StopMeter() error {
-	if err := w._powerMeter.Stop(); err != nil {
-		return err
-	} else {
-		w._powerMeter = powerMeter.New(w.config.Wattsup)
-		return nil
+// StopMeter stops the power meter and returns the path of its log. Stopping
+// a meter already stopped does nothing and returns no log.
+func (w *ServerWorker) StopMeter(ctx context.Context) (string, error) {
+	if !w.HasPowerMeter {
+		return "", errNoPowerMeter
 	}
+	if !w.meter.Running() {
+		return "", nil
+	}
+	path := w.GetMeterPath()
+	if err := w.meter.Stop(); err != nil {
+		w.meterErr = err
+		return "", err
+	}
+	w.meterErr = nil
+	return path, nil
 }
 
-func (w *ServerWorker) verifyImage(ID string) bool {
-	if _, _, err := w._docker.ImageInspectWithRaw(context.Background(), ID); err != nil {
+func (w *ServerWorker) verifyImage(ctx context.Context, ID string) bool {
+	if _, _, err := w._docker.ImageInspectWithRaw(ctx, ID); err != nil {
 		log.Println(err)
 		log.Println("Attempting to pull image...")
-		if _, err := w._docker.ImagePull(context.Background(), ID, types.ImagePullOptions{}); err != nil {
+		if _, err := w._docker.ImagePull(ctx, ID, types.ImagePullOptions{}); err != nil {
 			log.Println(err)
 			log.Println("Failed to pull image...")
 		}
@@ -114,21 +155,77 @@ func (w *ServerWorker) verifyContainer(ID string) bool {
 	return false
 }
 
-func (w *ServerWorker) StartJob(image string, cmd []string, duration int) (string, error) {
+// RunningJobs and jobsToKill are written by the handlers, the reconciler
+// and the exit watchers. Their own lock does not cover iterating them, so
+// writes go through setJob and deleteJob, and iterations through jobIDs and
+// runningJobs, which hold jobsMu.
+
+func (w *ServerWorker) setJob(jobs *job.SharedDockerJobsMap, ID string, j job.DockerJob) {
+	w.jobsMu.Lock()
+	jobs.Update(ID, j)
+	w.jobsMu.Unlock()
+}
+
+func (w *ServerWorker) deleteJob(jobs *job.SharedDockerJobsMap, ID string) {
+	w.jobsMu.Lock()
+	jobs.Delete(ID)
+	w.jobsMu.Unlock()
+}
+
+func (w *ServerWorker) jobIDs(jobs *job.SharedDockerJobsMap) []string {
+	w.jobsMu.RLock()
+	defer w.jobsMu.RUnlock()
+	return jobs.Keys()
+}
+
+// runningJobs returns a copy of RunningJobs.
+func (w *ServerWorker) runningJobs() map[string]job.DockerJob {
+	w.jobsMu.RLock()
+	defer w.jobsMu.RUnlock()
+	jobs := w.RunningJobs.Snap()
+	copied := make(map[string]job.DockerJob, len(jobs))
+	for id, j := range jobs {
+		copied[id] = j
+	}
+	return copied
+}
+
+func (w *ServerWorker) StartJob(ctx context.Context, j JobSpec) (string, error) {
+	if err := j.Validate(); err != nil {
+		return "", err
+	}
+	if w.isDraining() {
+		return "", ErrWorkerDraining
+	}
+	// syncMu keeps other jobs from reserving resources until this one is
+	// registered, and syncJobs from listing its container before, taking
+	// it for an orphan
+	w.syncMu.Lock()
+	defer w.syncMu.Unlock()
+	res, err := w.checkResources(ctx, &j)
+	if err != nil {
+		return "", err
+	}
+	if err := w.admit(ctx); err != nil {
+		log.Print(err)
+		return "", err
+	}
+
 	// verify image exists
-	if !w.verifyImage(image) {
+	if !w.verifyImage(ctx, j.Image) {
 		return "", errors.New("image does not exist")
 	}
 
 	// create image
-	resp, err := w._docker.ContainerCreate(context.Background(),
-		&container.Config{
-			Image: image,
-			Cmd:   cmd,
-		},
-		&container.HostConfig{
-			AutoRemove: true,
-		},
+	jobID, err := newJobID()
+	if err != nil {
+		return "", err
+	}
+	submitted := time.Now()
+	j.Labels = w.jobLabels(j, w.ownerLabels(j, jobID, submitted, res))
+	resp, err := w._docker.ContainerCreate(ctx,
+		j.containerConfig(),
+		j.hostConfig(),
 		nil,
 		nil,
 		"",
@@ -138,100 +235,144 @@ func (w *ServerWorker) StartJob(image string, cmd []string, duration int) (strin
 		return "", err
 	}
 
-	// start image
-	if err := w._docker.ContainerStart(context.Background(), resp.ID, types.ContainerStartOptions{}); err != nil {
+	// start image, waiting on its exit first as docker removes it right away
+	resultC, errC, cancel := w.waitExit(resp.ID)
+	if err := w._docker.ContainerStart(ctx, resp.ID, types.ContainerStartOptions{}); err != nil {
+		cancel()
 		log.Print(err)
 		return "", err
 	}
 
-	log.Print("started job ", duration)
+	log.Print("started job ", j.Duration)
 
 	// update list of running jobs
 	newCtr := job.DockerJob{
 		BaseJob: job.BaseJob{
 			StartTime:    time.Now(),
 			TotalRunTime: time.Duration(0),
-			Duration:     time.Duration(duration) * time.Second,
+			Duration:     time.Duration(j.Duration) * time.Second,
 		},
-		Container: types.Container{ID: resp.ID},
+		Container: types.Container{ID: resp.ID, Labels: j.Labels},
 	}
-	w.RunningJobs.Update(resp.ID, newCtr)
+	w.setJob(&w.RunningJobs, resp.ID, newCtr)
+	w.history.started(JobRecord{ID: resp.ID, JobID: jobID, Spec: j, StartTime: newCtr.StartTime})
+	w.watchJob(resp.ID, resultC, errC, cancel)
+	w.sampleEnergy(ctx, resp.ID)
 
 	return resp.ID, nil
 }
 
-func (w *ServerWorker) StopJob(ID string) error {
+func (w *ServerWorker) StopJob(ctx context.Context, ID string) (JobResult, error) {
+	return w.stopJob(ctx, ID, ReasonStopped)
+}
+
+// stopJob stops a job and records it in the history as stopped for reason,
+// along with its exit code when docker reports it in time.
+func (w *ServerWorker) stopJob(ctx context.Context, ID string, reason TerminationReason) (JobResult, error) {
 	if w.verifyContainer(ID) {
-		if err := w._docker.ContainerStop(context.Background(), ID, nil); err != nil {
-			return err
+		w.sampleEnergy(ctx, ID)
+		w.history.stopping(ID, reason)
+		if err := w._docker.ContainerStop(ctx, ID, nil); err != nil {
+			w.history.stopping(ID, "")
+			return JobResult{}, err
 		}
 	} else {
-		return errors.New("failed to stop: Job ID not found")
+		return JobResult{}, fmt.Errorf("failed to stop: %w", ErrJobNotFound)
 	}
 
 	ctr, _ := w.RunningJobs.Get(ID)
 	ctr.UpdateTotalRunTime(time.Now())
-	log.Printf("Stopped %s, total run time: %s", ID, ctr.TotalRunTime)
-	return nil
+	w.waitExited(ID)
+	r, ok := w.history.finish(ID, reason, nil, w.energy.remove(ID))
+	if !ok {
+		r, _ = w.history.find(ID)
+	}
+	w.deleteJob(&w.RunningJobs, ID)
+	log.Printf("Stopped %s (%s), total run time: %s, energy: %.1f J", ID, reason, ctr.TotalRunTime, r.Energy)
+	return JobResult{ID: ID, TotalRunTime: ctr.TotalRunTime, Energy: r.Energy}, nil
 }
 
-func (w *ServerWorker) updateGetRunningJobs(containers []types.Container) (job.SharedDockerJobsMap, error) {
+// syncJobs lists the worker's containers and syncs RunningJobs with them,
+// then kills the jobs that outlived their duration along with orphan
+// containers. syncMu is held from listing to syncing, and by StartJob while
+// it registers a job, so jobs started meanwhile are neither dropped nor
+// taken for orphans. Kills happen after, as they can take a while.
+func (w *ServerWorker) syncJobs(ctx context.Context) ([]types.Container, error) {
+	w.syncMu.Lock()
+	containers, err := w.listContainers(ctx)
+	if err != nil {
+		w.syncMu.Unlock()
+		return nil, err
+	}
+	w.updateRunningJobs(containers)
+	w.syncMu.Unlock()
+	w.killJobs(ctx)
+	return containers, nil
+}
+
+// updateRunningJobs syncs RunningJobs with the containers docker reports,
+// queuing jobs that outlived their duration along with orphan containers in
+// jobsToKill. Callers hold syncMu unless nothing else runs yet.
+func (w *ServerWorker) updateRunningJobs(containers []types.Container) {
 	ids := make([]string, 0)
 	for _, container := range containers {
-		if container.ID[:12] != w.Hostname {
-			// found existing job
-			if w.verifyContainer(container.ID) {
-				base, _ := w.RunningJobs.Get(container.ID)
-				updatedCtr := job.DockerJob{
-					BaseJob:   base.BaseJob,
-					Container: container,
-				}
-				updatedCtr.UpdateTotalRunTime(time.Now())
-				if updatedCtr.TotalRunTime >= updatedCtr.Duration {
-					w.jobsToKill.Update(updatedCtr.ID, updatedCtr)
-				}
-			} else { // found orphan job
-				newCtr := job.DockerJob{
-					BaseJob: job.BaseJob{
-						StartTime:    time.Now(),
-						TotalRunTime: time.Duration(0),
-						Duration:     time.Duration(-1),
-					},
-					Container: types.Container{ID: container.ID},
-				}
-				w.jobsToKill.Update(newCtr.ID, newCtr)
-				w.RunningJobs.Update(container.ID, newCtr)
+		// found existing job
+		if w.verifyContainer(container.ID) {
+			base, _ := w.RunningJobs.Get(container.ID)
+			updatedCtr := job.DockerJob{
+				BaseJob:   base.BaseJob,
+				Container: container,
+			}
+			updatedCtr.UpdateTotalRunTime(time.Now())
+			// adopted containers may have no deadline
+			if updatedCtr.Duration != 0 && updatedCtr.TotalRunTime >= updatedCtr.Duration {
+				w.setJob(&w.jobsToKill, updatedCtr.ID, updatedCtr)
 			}
-			ids = append(ids, container.ID)
+		} else if !w.handleOrphan(container) {
+			continue
 		}
+		ids = append(ids, container.ID)
 	}
 
-	// remove stale jobs
-	w.killJobs()
+	// remove stale jobs, keeping the ones queued to be killed
+	w.jobsMu.Lock()
 	w.RunningJobs.Refresh(ids)
-	return w.RunningJobs, nil
+	w.jobsMu.Unlock()
+
+	// jobs gone from docker without being stopped exited on their own, and
+	// are recorded here when their exit status is not waited on
+	running := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		running[id] = true
+	}
+	for _, id := range w.history.tracked() {
+		if _, waiting := w.exits.get(id); running[id] || waiting {
+			continue
+		}
+		if r, ok := w.history.finish(id, ReasonCompleted, nil, w.energy.remove(id)); ok {
+			log.Printf("Finished %s, total run time: %s, energy: %.1f J", id, r.TotalRunTime, r.Energy)
+		}
+	}
+	w.energy.keep(ids)
 }
 
-func (w *ServerWorker) GetRunningJobs() (map[string]job.DockerJob, error) {
-	containers, err := w._docker.ContainerList(context.Background(), types.ContainerListOptions{})
-	if err != nil {
+func (w *ServerWorker) GetRunningJobs(ctx context.Context) (map[string]job.DockerJob, error) {
+	if _, err := w.syncJobs(ctx); err != nil {
 		return nil, err
 	}
-	RunningJobs, _ := w.updateGetRunningJobs(containers)
-	return RunningJobs.Snap(), nil
+	return w.runningJobs(), nil
 }
 
-func (w *ServerWorker) GetRunningJobsStats() (map[string][]byte, error) {
-	containers, err := w._docker.ContainerList(context.Background(), types.ContainerListOptions{})
+func (w *ServerWorker) GetRunningJobsStats(ctx context.Context) (map[string][]byte, error) {
+	containers, err := w.syncJobs(ctx)
 	if err != nil {
 		return nil, err
 	}
-	w.updateGetRunningJobs(containers)
 
 	var containerStats map[string][]byte = make(map[string][]byte)
 	for _, container := range containers {
-		if container.ID[:12] != w.Hostname {
-			stats, err := w._docker.ContainerStatsOneShot(context.Background(), container.ID)
+		if w.verifyContainer(container.ID) {
+			stats, err := w._docker.ContainerStatsOneShot(ctx, container.ID)
 			if err != nil {
 				log.Print(err)
 				log.Println("Failed to get stats for {}", container.ID)
@@ -242,44 +383,79 @@ func (w *ServerWorker) GetRunningJobsStats() (map[string][]byte, error) {
 			if err != nil {
 				log.Print(err)
 			}
+			w.accountEnergy(container.ID, raw_stats)
 			containerStats[container.ID] = raw_stats
 		}
 	}
 	return containerStats, nil
 }
 
-func (w *ServerWorker) Stats() (map[string]interface{}, error) {
+func (w *ServerWorker) Stats(ctx context.Context) (map[string]interface{}, error) {
 	stats, err := profile.Get11Stats()
 	if err != nil {
 		return nil, err
 	}
+	w.recordUtilization(stats)
+	w.calibrate(stats)
 	return stats, nil
 }
 
-func (w *ServerWorker) ReducedStats() (map[string]interface{}, error) {
+func (w *ServerWorker) ReducedStats(ctx context.Context) (map[string]interface{}, error) {
 	stats, err := profile.GetCPUAndMemStats()
 	if err != nil {
 		return nil, err
 	}
+	w.recordUtilization(stats)
+	w.calibrate(stats)
 	return stats, nil
 }
 
-func (w *ServerWorker) IsAvailable() bool {
-	return w.Available
+// IsAvailable reports whether the worker currently accepts jobs.
+func (w *ServerWorker) IsAvailable(ctx context.Context) (bool, error) {
+	return w.refreshState(ctx).Accepting(), nil
 }
 
 func (w *ServerWorker) PowerMeterOn() bool {
 	return w.HasPowerMeter
 }
 
-func (w *ServerWorker) killJobs() error {
-	for _, id := range w.jobsToKill.Keys() {
-		if err := w.StopJob(id); err != nil {
+// killJobs stops the jobs queued in jobsToKill. killMu keeps concurrent
+// syncs from stopping the same job twice.
+func (w *ServerWorker) killJobs(ctx context.Context) error {
+	w.killMu.Lock()
+	defer w.killMu.Unlock()
+	for _, id := range w.jobIDs(&w.jobsToKill) {
+		reason := ReasonDurationExceeded
+		if j, _ := w.jobsToKill.Get(id); j.Duration < 0 {
+			reason = ReasonOrphan
+		}
+		if _, err := w.stopJob(ctx, id, reason); err != nil {
 			log.Print(err)
 		} else {
-			w.jobsToKill.Delete(id)
-			w.RunningJobs.Delete(id)
+			w.deleteJob(&w.jobsToKill, id)
+			w.deleteJob(&w.RunningJobs, id)
 		}
 	}
 	return nil
 }
+
+// Shutdown stops the power meter and every job still running on the worker.
+func (w *ServerWorker) Shutdown() error {
+	var errs []string
+	if w.HasPowerMeter && w.meter.Running() {
+		if err := w.meter.Stop(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	for _, id := range w.jobIDs(&w.RunningJobs) {
+		if _, err := w.StopJob(context.Background(), id); err != nil {
+			errs = append(errs, err.Error())
+		} else {
+			w.deleteJob(&w.RunningJobs, id)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	job "github.com/Nguyen-Hoa/job"
+	"github.com/docker/docker/api/types"
+)
+
+// TestRunningJobsCopy encodes RunningJobs while jobs come and go, which is
+// only safe on copies. Run with -race.
+func TestRunningJobsCopy(t *testing.T) {
+	w := &ServerWorker{}
+	w.RunningJobs.Init()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			id := fmt.Sprint(i)
+			w.setJob(&w.RunningJobs, id, job.DockerJob{Container: types.Container{ID: id}})
+			w.deleteJob(&w.RunningJobs, fmt.Sprint(i-1))
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		if _, err := json.Marshal(w.runningJobs()); err != nil {
+			t.Fatal(err)
+		}
+		w.reserved()
+		w.jobIDs(&w.RunningJobs)
+	}
+	wg.Wait()
+
+	jobs := w.runningJobs()
+	delete(jobs, "999")
+	if !w.verifyContainer("999") {
+		t.Error("deleting from the copy of RunningJobs deleted the job")
+	}
+}
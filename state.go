@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"log"
+)
+
+// State is where a worker is in its lifecycle, as reported by GetState.
+type State string
+
+const (
+	// StateStarting is the state of a worker until Init completes.
+	StateStarting State = "starting"
+	// StateReady workers accept jobs.
+	StateReady State = "ready"
+	// StateSaturated workers are over one of their thresholds.
+	StateSaturated State = "saturated"
+	// StateDraining workers let running jobs finish but refuse new ones.
+	StateDraining State = "draining"
+	// StateDegraded workers accept jobs, but their power meter is failing
+	// or their utilization could not be read.
+	StateDegraded State = "degraded"
+	// StateUnavailable workers cannot reach docker.
+	StateUnavailable State = "unavailable"
+)
+
+// Accepting reports whether a worker in state s takes new jobs.
+func (s State) Accepting() bool {
+	return s == StateReady || s == StateDegraded
+}
+
+// setState records s, keeping Available in line with it.
+func (w *ServerWorker) setState(s State) {
+	w.stateMu.Lock()
+	defer w.stateMu.Unlock()
+	if w.State != s {
+		log.Printf("%s: %s -> %s", w.Name, w.State, s)
+	}
+	w.State = s
+	w.Available = s.Accepting()
+}
+
+// refreshState re-evaluates the worker's state from docker connectivity,
+// meter health and its thresholds.
+func (w *ServerWorker) refreshState(ctx context.Context) State {
+	state := StateReady
+	if _, err := w._docker.Ping(ctx); err != nil {
+		log.Print(err)
+		state = StateUnavailable
+	} else if w.isDraining() {
+		state = StateDraining
+	} else if err := w.admit(ctx); errors.Is(err, ErrWorkerSaturated) {
+		state = StateSaturated
+	} else if err != nil || w.meterError() != nil {
+		state = StateDegraded
+	}
+	w.setState(state)
+	return state
+}
+
+// meterError is the error of the last StartMeter or StopMeter, or of the
+// meter failing while running.
+func (w *ServerWorker) meterError() error {
+	if w.meterErr != nil {
+		return w.meterErr
+	}
+	if h, ok := w.meter.(HealthReporter); ok && w.meter.Running() {
+		return h.Err()
+	}
+	return nil
+}
+
+// GetState reports the worker's current state.
+func (w *ServerWorker) GetState(ctx context.Context) (State, error) {
+	return w.refreshState(ctx), nil
+}
@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	job "github.com/Nguyen-Hoa/job"
+)
+
+// Transport carries ManagerWorker calls to a remote worker. Each protocol a
+// worker can be served over has its own implementation, picked by New from
+// the WorkerConfig.
+type Transport interface {
+	StartJob(ctx context.Context, j JobSpec) (string, error)
+	StopJob(ctx context.Context, ID string) (JobResult, error)
+	GetRunningJobs(ctx context.Context) (map[string]job.DockerJob, error)
+	GetRunningJobsStats(ctx context.Context) (map[string][]byte, error)
+	Poll(ctx context.Context) (map[string]interface{}, error)
+	ReducedStats(ctx context.Context) (map[string]interface{}, error)
+	StartMeter(ctx context.Context) error
+	StopMeter(ctx context.Context) (string, error)
+	GetState(ctx context.Context) (State, error)
+	Drain(ctx context.Context) error
+	Undrain(ctx context.Context) error
+	GetPowerModel(ctx context.Context) (*RegressionPowerModel, error)
+	MeterLog(ctx context.Context, q MeterLogQuery) ([]PowerReading, error)
+	PowerCapActions(ctx context.Context) ([]PowerCapAction, error)
+	ListJobHistory(ctx context.Context, q HistoryQuery) ([]JobRecord, error)
+	JobStatus(ctx context.Context, ID string) (JobStatus, error)
+	PowerMeterOn(ctx context.Context) (bool, error)
+	Close() error
+}
+
+// newTransport selects a Transport from the protocol settings in config.
+func newTransport(config WorkerConfig) (Transport, error) {
+	if config.RPCServer && config.RPCPort != "" {
+		return DialRPC(config.Address + config.RPCPort)
+	} else if config.GRPCServer && config.GRPCPort != "" {
+		return DialGRPC(config.Address + config.GRPCPort)
+	} else if config.HTTPPort != "" {
+		return NewHTTPTransport("http://"+config.Address+config.HTTPPort, http.DefaultClient), nil
+	}
+	return nil, errors.New("no valid rpc, grpc or http configuration provided")
+}
@@ -0,0 +1,226 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	job "github.com/Nguyen-Hoa/job"
+	"github.com/docker/docker/api/types"
+)
+
+// testMeterStart times the readings of the test meter, which only count
+// while recent.
+var testMeterStart = time.Now().Truncate(time.Second)
+
+func intPtr(n int) *int {
+	return &n
+}
+
+// initTestWorker sets up w without docker: one job running, a finished job
+// of each outcome in its history and a running fake meter over its
+// threshold. Only calls that never reach docker can be made on it.
+func initTestWorker(t *testing.T, w *ServerWorker) {
+	t.Helper()
+	w.Name = "test"
+	w.Cores = 2
+	w.PowerThresh = 90
+	w.RunningJobs.Init()
+	w.jobsToKill.Init()
+	w.energy.init()
+	w.history.init(filepath.Join(t.TempDir(), "history.jsonl"))
+
+	w.setJob(&w.RunningJobs, "running", job.DockerJob{
+		BaseJob:   job.BaseJob{StartTime: time.Now()},
+		Container: types.Container{ID: "running"},
+	})
+	finished := []struct {
+		ID       string
+		stopping TerminationReason
+		exitCode int
+		energy   float64
+	}{
+		{"succeeded", "", 0, 1.5},
+		{"failed", "", 3, 2.5},
+		{"stopped", ReasonStopped, 137, 12.5},
+	}
+	for _, f := range finished {
+		w.history.started(JobRecord{ID: f.ID, StartTime: time.Now().Add(-time.Minute)})
+		if f.stopping != "" {
+			w.history.stopping(f.ID, f.stopping)
+		}
+		w.history.finish(f.ID, ReasonCompleted, intPtr(f.exitCode), jobUsage{Energy: f.energy})
+	}
+
+	meter := NewFakePowerMeter(100, time.Hour)
+	if err := meter.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { meter.Stop() })
+	for i, watts := range []float32{80, 100, 120} {
+		meter.Record(PowerReading{Time: testMeterStart.Add(time.Duration(i) * time.Second), Watts: watts})
+	}
+	w.meter = meter
+	w.HasPowerMeter = true
+	w.SetPowerModel(&LinearPowerModel{Idle: 50, Max: 150})
+}
+
+// testTransport runs the calls a manager makes through tr, against a worker
+// set up by initTestWorker, checking errors keep their identity.
+func testTransport(t *testing.T, tr Transport) {
+	ctx := context.Background()
+
+	t.Run("StartJob", func(t *testing.T) {
+		tests := []struct {
+			name string
+			spec JobSpec
+			want error
+		}{
+			{"invalid", JobSpec{}, ErrInvalidJobSpec},
+			{"over cores", JobSpec{Job: job.Job{Image: "alpine", Duration: 10}, CPUs: 4}, ErrResourceLimit},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := tr.StartJob(ctx, tt.spec)
+				if !errors.Is(err, tt.want) {
+					t.Fatalf("StartJob() error = %v, want %v", err, tt.want)
+				}
+			})
+		}
+
+		_, err := tr.StartJob(ctx, JobSpec{Job: job.Job{Image: "alpine", Duration: 10}, CPUs: 1})
+		if !errors.Is(err, ErrWorkerSaturated) {
+			t.Fatalf("StartJob() error = %v, want %v", err, ErrWorkerSaturated)
+		}
+		var saturated *SaturatedError
+		if !errors.As(err, &saturated) {
+			t.Fatalf("StartJob() error = %v, want a *SaturatedError", err)
+		}
+		if want := (SaturatedError{Resource: "power", Value: 120, Thresh: 90}); *saturated != want {
+			t.Errorf("StartJob() error = %+v, want %+v", *saturated, want)
+		}
+	})
+
+	t.Run("StopJob", func(t *testing.T) {
+		if _, err := tr.StopJob(ctx, "missing"); !errors.Is(err, ErrJobNotFound) {
+			t.Fatalf("StopJob() error = %v, want %v", err, ErrJobNotFound)
+		}
+	})
+
+	t.Run("JobStatus", func(t *testing.T) {
+		tests := []struct {
+			ID   string
+			want JobStatus
+		}{
+			{"running", JobStatus{ID: "running", State: JobRunning}},
+			{"succeeded", JobStatus{ID: "succeeded", State: JobSucceeded, ExitCode: intPtr(0), Reason: ReasonCompleted}},
+			{"failed", JobStatus{ID: "failed", State: JobFailed, ExitCode: intPtr(3), Reason: ReasonCompleted}},
+			{"stopped", JobStatus{ID: "stopped", State: JobKilled, ExitCode: intPtr(137), Reason: ReasonStopped}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.ID, func(t *testing.T) {
+				got, err := tr.JobStatus(ctx, tt.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !equalJobStatus(got, tt.want) {
+					t.Errorf("JobStatus() = %s, want %s", formatJobStatus(got), formatJobStatus(tt.want))
+				}
+			})
+		}
+		if _, err := tr.JobStatus(ctx, "missing"); !errors.Is(err, ErrJobNotFound) {
+			t.Errorf("JobStatus() error = %v, want %v", err, ErrJobNotFound)
+		}
+	})
+
+	t.Run("ListJobHistory", func(t *testing.T) {
+		records, err := tr.ListJobHistory(ctx, HistoryQuery{Reason: ReasonCompleted})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) != 2 || records[0].ID != "succeeded" || records[1].ID != "failed" {
+			t.Fatalf("ListJobHistory() = %+v, want succeeded and failed", records)
+		}
+		for i, want := range []int{0, 3} {
+			if code := records[i].ExitCode; code == nil || *code != want {
+				t.Errorf("ListJobHistory() exit code of %s = %v, want %d", records[i].ID, code, want)
+			}
+		}
+
+		records, err = tr.ListJobHistory(ctx, HistoryQuery{Limit: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) != 1 || records[0].ID != "stopped" || records[0].Energy != 12.5 {
+			t.Errorf("ListJobHistory() = %+v, want the stopped job", records)
+		}
+	})
+
+	t.Run("MeterLog", func(t *testing.T) {
+		readings, err := tr.MeterLog(ctx, MeterLogQuery{From: testMeterStart.Add(time.Second)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []PowerReading{
+			{Time: testMeterStart.Add(time.Second), Watts: 100},
+			{Time: testMeterStart.Add(2 * time.Second), Watts: 120},
+		}
+		if !equalReadings(readings, want) {
+			t.Errorf("MeterLog() = %v, want %v", readings, want)
+		}
+	})
+
+	t.Run("GetPowerModel", func(t *testing.T) {
+		m, err := tr.GetPowerModel(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &RegressionPowerModel{Intercept: 50, Coefficients: map[string]float64{"cpupercent": 1}}
+		if !m.equal(want) {
+			t.Errorf("GetPowerModel() = %+v, want %+v", m, want)
+		}
+	})
+
+	t.Run("PowerMeterOn", func(t *testing.T) {
+		on, err := tr.PowerMeterOn(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !on {
+			t.Error("PowerMeterOn() = false, want true")
+		}
+	})
+}
+
+func equalJobStatus(a, b JobStatus) bool {
+	if a.ID != b.ID || a.State != b.State || a.Reason != b.Reason {
+		return false
+	}
+	if a.ExitCode == nil || b.ExitCode == nil {
+		return a.ExitCode == b.ExitCode
+	}
+	return *a.ExitCode == *b.ExitCode
+}
+
+func formatJobStatus(s JobStatus) string {
+	code := "none"
+	if s.ExitCode != nil {
+		code = fmt.Sprint(*s.ExitCode)
+	}
+	return fmt.Sprintf("{%s %s %s exit %s}", s.ID, s.State, s.Reason, code)
+}
+
+func equalReadings(a, b []PowerReading) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Time.Equal(b[i].Time) || a[i].Watts != b[i].Watts {
+			return false
+		}
+	}
+	return true
+}
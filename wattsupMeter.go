@@ -0,0 +1,160 @@
+package worker
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	powerMeter "github.com/Nguyen-Hoa/wattsup"
+)
+
+// WattsupMeter runs the wattsup meter, which writes its readings to a log,
+// and follows the log to time each reading as it is written.
+type WattsupMeter struct {
+	args   powerMeter.WattsupArgs
+	meter  *powerMeter.Wattsup
+	tail   *meterTail
+	log    readingLog
+	health meterHealth
+}
+
+// NewWattsupMeter creates the log the meter will write to on Start.
+func NewWattsupMeter(args powerMeter.WattsupArgs) *WattsupMeter {
+	return &WattsupMeter{args: args, meter: powerMeter.New(args)}
+}
+
+// Path is the log the meter writes to, or will on its next Start.
+func (m *WattsupMeter) Path() string {
+	if m.meter == nil {
+		return ""
+	}
+	return m.meter.Fullpath
+}
+
+func (m *WattsupMeter) Start() error {
+	if m.meter == nil {
+		if m.meter = powerMeter.New(m.args); m.meter == nil {
+			return errors.New("failed to create wattsup log")
+		}
+	}
+	if err := writeWattsupStart(m.meter.Fullpath, time.Now()); err != nil {
+		log.Print(err)
+	}
+	if err := m.meter.Start(); err != nil {
+		return err
+	}
+	tail, err := tailMeter(m.meter.Fullpath, &m.log, &m.health)
+	if err != nil {
+		return err
+	}
+	m.log.reset()
+	m.health.reset()
+	m.tail = tail
+	return nil
+}
+
+// Stop stops the meter and creates the log of its next run.
+func (m *WattsupMeter) Stop() error {
+	if m.tail != nil {
+		m.tail.close()
+		m.tail = nil
+	}
+	if !m.Running() {
+		return errors.New("power meter not running")
+	}
+	if err := m.meter.Stop(); err != nil {
+		return err
+	}
+	m.meter = powerMeter.New(m.args)
+	return nil
+}
+
+func (m *WattsupMeter) Running() bool {
+	return m.meter != nil && m.meter.Running()
+}
+
+func (m *WattsupMeter) Interval() time.Duration {
+	return wattsupInterval
+}
+
+func (m *WattsupMeter) Latest() (PowerReading, bool) {
+	return m.log.latest()
+}
+
+func (m *WattsupMeter) Samples() []PowerReading {
+	return m.log.samples()
+}
+
+// Err reports the log failing to be read. A meter writing nothing for
+// maxFailedReads intervals is failing too.
+func (m *WattsupMeter) Err() error {
+	return m.health.Err()
+}
+
+// meterTail follows the log of a running wattsup meter, timing each
+// reading when it is written.
+type meterTail struct {
+	log    *readingLog
+	health *meterHealth
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+func tailMeter(path string, readings *readingLog, health *meterHealth) (*meterTail, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	t := &meterTail{log: readings, health: health, stop: make(chan struct{}), done: make(chan struct{})}
+	go t.follow(f)
+	return t, nil
+}
+
+func (t *meterTail) follow(f *os.File) {
+	defer close(t.done)
+	defer f.Close()
+
+	ticker := time.NewTicker(wattsupInterval / 4)
+	defer ticker.Stop()
+	r := bufio.NewReader(f)
+	var partial string
+	lastRead := time.Now()
+	for {
+		line, err := r.ReadString('\n')
+		partial += line
+		if err == nil {
+			if w, ok := parseWatts(strings.TrimSpace(partial)); ok {
+				t.log.add(PowerReading{Time: time.Now(), Watts: w})
+				t.health.record(nil)
+				lastRead = time.Now()
+			} else {
+				t.health.record(fmt.Errorf("no watts in meter output: %q", partial))
+			}
+			partial = ""
+			continue
+		}
+		if err != io.EOF {
+			log.Print(err)
+			t.health.fail(err)
+			return
+		}
+		if silent := time.Since(lastRead); silent > maxFailedReads*wattsupInterval {
+			t.health.fail(fmt.Errorf("no power reading for %s", silent.Round(time.Second)))
+		}
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *meterTail) close() {
+	close(t.stop)
+	<-t.done
+}
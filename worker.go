@@ -1,7 +1,9 @@
 package worker
 
 import (
-	"net/rpc"
+	"context"
+	"sync"
+	"time"
 
 	job "github.com/Nguyen-Hoa/job"
 	powerMeter "github.com/Nguyen-Hoa/wattsup"
@@ -20,7 +22,25 @@ type WorkerConfig struct {
 	RPCServer    bool                   `json:"rpcServer"`
 	RPCPort      string                 `json:"rpcPort"`
 	HTTPPort     string                 `json:"httpPort"`
+	GRPCServer   bool                   `json:"grpcServer"`
+	GRPCPort     string                 `json:"grpcPort"`
 	Wattsup      powerMeter.WattsupArgs `json:"wattsup"`
+	PowerMeter   PowerMeterConfig       `json:"powerMeter"`
+	// ClampResources lowers job resource requests over the worker's
+	// limits instead of rejecting the job.
+	ClampResources bool `json:"clampResources"`
+	// PowerModel predicts the worker's power in place of the linear model
+	// over DynamicRange.
+	PowerModel  *RegressionPowerModel `json:"powerModel,omitempty"`
+	Calibration CalibrationConfig     `json:"calibration"`
+	PowerCap    PowerCapConfig        `json:"powerCap"`
+	Orphans     OrphanConfig          `json:"orphans"`
+	// ReconcileInterval is the time between syncs with docker, in seconds,
+	// 10 when 0.
+	ReconcileInterval float64 `json:"reconcileInterval,omitempty"`
+	// HistoryPath is the JSON lines file the history of finished jobs is
+	// appended to. No history is kept when empty.
+	HistoryPath string `json:"historyPath,omitempty"`
 }
 
 /* --------------------
@@ -39,12 +59,14 @@ type worker struct {
 	RPCServer     bool
 	RPCPort       string
 	HTTPPort      string
-	rpcClient     *rpc.Client
+	GRPCServer    bool
+	GRPCPort      string
 	config        WorkerConfig
 	HasPowerMeter bool
 
 	// status
 	Available            bool
+	State                State
 	LatestActualPower    float32
 	LatestPredictedPower float32
 	LatestCPU            float32
@@ -58,21 +80,87 @@ type worker struct {
 /* --------------------
 Worker (Abstract)
 ----------------------*/
-// type Worker interface {
-// 	Init()
-// 	StartMeter()
-// 	StopMeter()
-// 	StartJob()
-// 	StopJob()
-// 	Poll()
-// 	IsAvailable()
-// }
+// Worker is implemented by the in-process ServerWorker, the RPCServerWorker
+// and GRPCServerWorker that serve it remotely, and the ManagerWorker client,
+// so schedulers can be written once against either a local or a remote
+// worker. It covers running jobs, stats, metering and availability; the
+// workers of this package also implement the optional interfaces below.
+type Worker interface {
+	StartJob(ctx context.Context, j JobSpec) (string, error)
+	StopJob(ctx context.Context, ID string) (JobResult, error)
+	GetRunningJobs(ctx context.Context) (map[string]job.DockerJob, error)
+	Stats(ctx context.Context) (map[string]interface{}, error)
+	ReducedStats(ctx context.Context) (map[string]interface{}, error)
+	StartMeter(ctx context.Context) error
+	StopMeter(ctx context.Context) (string, error)
+	IsAvailable(ctx context.Context) (bool, error)
+}
+
+// Optional interfaces of a Worker, for schedulers to check with a type
+// assertion, e.g. w.(JobStatusReporter), so other Worker implementations
+// need not provide them.
+type (
+	Drainer interface {
+		GetState(ctx context.Context) (State, error)
+		Drain(ctx context.Context) error
+		Undrain(ctx context.Context) error
+		WaitDrained(ctx context.Context) error
+	}
+	JobStatusReporter interface {
+		JobStatus(ctx context.Context, ID string) (JobStatus, error)
+	}
+	JobHistoryLister interface {
+		ListJobHistory(ctx context.Context, q HistoryQuery) ([]JobRecord, error)
+	}
+	PowerModelProvider interface {
+		GetPowerModel(ctx context.Context) (*RegressionPowerModel, error)
+	}
+	MeterLogReader interface {
+		MeterLog(ctx context.Context, q MeterLogQuery) ([]PowerReading, error)
+	}
+	PowerCapReporter interface {
+		PowerCapActions(ctx context.Context) ([]PowerCapAction, error)
+	}
+)
+
+// JobResult describes a job once it has been stopped.
+type JobResult struct {
+	ID           string        `json:"id"`
+	TotalRunTime time.Duration `json:"totalRunTime"`
+	// Energy is the energy attributed to the job, in joules
+	Energy float64 `json:"energy"`
+}
+
+var (
+	_ Worker = (*ServerWorker)(nil)
+	_ Worker = (*RPCServerWorker)(nil)
+	_ Worker = (*GRPCServerWorker)(nil)
+	_ Worker = (*ManagerWorker)(nil)
+
+	_ Drainer            = (*ServerWorker)(nil)
+	_ Drainer            = (*ManagerWorker)(nil)
+	_ JobStatusReporter  = (*ServerWorker)(nil)
+	_ JobStatusReporter  = (*ManagerWorker)(nil)
+	_ JobHistoryLister   = (*ServerWorker)(nil)
+	_ JobHistoryLister   = (*ManagerWorker)(nil)
+	_ PowerModelProvider = (*ServerWorker)(nil)
+	_ PowerModelProvider = (*ManagerWorker)(nil)
+	_ MeterLogReader     = (*ServerWorker)(nil)
+	_ MeterLogReader     = (*ManagerWorker)(nil)
+	_ PowerCapReporter   = (*ServerWorker)(nil)
+	_ PowerCapReporter   = (*ManagerWorker)(nil)
+)
 
 /* --------------------
 Manager Worker
 ----------------------*/
 type ManagerWorker struct {
 	worker
+
+	transport Transport
+	handles   map[string]*JobHandle
+	handlesMu sync.Mutex
+	statsMu   sync.Mutex
 }
 
 /* --------------------
@@ -81,16 +169,38 @@ HTTP Server Worker
 type ServerWorker struct {
 	worker
 
-	_powerMeter *powerMeter.Wattsup
+	meter       PowerMeter
 	_docker     *client.Client
+	ncpu        int
+	memTotal    int64
+	meterErr    error
+	powerModel  PowerModel
+	modelMu     sync.RWMutex
+	calibrator  *calibrator
+	meterEnergy meterEnergy
+	powerCap    powerCapper
+	syncMu      sync.Mutex
+	killMu      sync.Mutex
+	jobsMu      sync.RWMutex
+	history     jobHistory
+	exits       exitWatchers
+	energy      energyAccount
+	draining    bool
+	stateMu     sync.Mutex
+	utilMu      sync.Mutex
+	utilAt      time.Time
 }
 
 /* --------------------
 RPC Server Worker
 ----------------------*/
 type RPCServerWorker struct {
-	worker
+	ServerWorker
+}
 
-	_powerMeter *powerMeter.Wattsup
-	_docker     *client.Client
+/* --------------------
+gRPC Server Worker
+----------------------*/
+type GRPCServerWorker struct {
+	ServerWorker
 }
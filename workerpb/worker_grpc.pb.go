@@ -0,0 +1,731 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: worker.proto
+
+package workerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Worker_StartJob_FullMethodName            = "/worker.Worker/StartJob"
+	Worker_StopJob_FullMethodName             = "/worker.Worker/StopJob"
+	Worker_GetRunningJobs_FullMethodName      = "/worker.Worker/GetRunningJobs"
+	Worker_GetRunningJobsStats_FullMethodName = "/worker.Worker/GetRunningJobsStats"
+	Worker_Poll_FullMethodName                = "/worker.Worker/Poll"
+	Worker_ReducedStats_FullMethodName        = "/worker.Worker/ReducedStats"
+	Worker_StartMeter_FullMethodName          = "/worker.Worker/StartMeter"
+	Worker_StopMeter_FullMethodName           = "/worker.Worker/StopMeter"
+	Worker_MeterLog_FullMethodName            = "/worker.Worker/MeterLog"
+	Worker_IsAvailable_FullMethodName         = "/worker.Worker/IsAvailable"
+	Worker_Drain_FullMethodName               = "/worker.Worker/Drain"
+	Worker_Undrain_FullMethodName             = "/worker.Worker/Undrain"
+	Worker_PowerMeterOn_FullMethodName        = "/worker.Worker/PowerMeterOn"
+	Worker_GetPowerModel_FullMethodName       = "/worker.Worker/GetPowerModel"
+	Worker_PowerCapActions_FullMethodName     = "/worker.Worker/PowerCapActions"
+	Worker_ListJobHistory_FullMethodName      = "/worker.Worker/ListJobHistory"
+	Worker_JobStatus_FullMethodName           = "/worker.Worker/JobStatus"
+)
+
+// WorkerClient is the client API for Worker service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WorkerClient interface {
+	StartJob(ctx context.Context, in *StartJobRequest, opts ...grpc.CallOption) (*StartJobReply, error)
+	StopJob(ctx context.Context, in *StopJobRequest, opts ...grpc.CallOption) (*StopJobReply, error)
+	GetRunningJobs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RunningJobsReply, error)
+	GetRunningJobsStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RunningJobsStatsReply, error)
+	Poll(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*structpb.Struct, error)
+	ReducedStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*structpb.Struct, error)
+	StartMeter(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	StopMeter(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*StopMeterReply, error)
+	MeterLog(ctx context.Context, in *MeterLogRequest, opts ...grpc.CallOption) (Worker_MeterLogClient, error)
+	IsAvailable(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IsAvailableReply, error)
+	Drain(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IsAvailableReply, error)
+	Undrain(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IsAvailableReply, error)
+	PowerMeterOn(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PowerMeterOnReply, error)
+	GetPowerModel(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PowerModel, error)
+	PowerCapActions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PowerCapActionsReply, error)
+	ListJobHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*JobHistoryReply, error)
+	JobStatus(ctx context.Context, in *JobStatusRequest, opts ...grpc.CallOption) (*JobStatusReply, error)
+}
+
+type workerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWorkerClient(cc grpc.ClientConnInterface) WorkerClient {
+	return &workerClient{cc}
+}
+
+func (c *workerClient) StartJob(ctx context.Context, in *StartJobRequest, opts ...grpc.CallOption) (*StartJobReply, error) {
+	out := new(StartJobReply)
+	err := c.cc.Invoke(ctx, Worker_StartJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) StopJob(ctx context.Context, in *StopJobRequest, opts ...grpc.CallOption) (*StopJobReply, error) {
+	out := new(StopJobReply)
+	err := c.cc.Invoke(ctx, Worker_StopJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) GetRunningJobs(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RunningJobsReply, error) {
+	out := new(RunningJobsReply)
+	err := c.cc.Invoke(ctx, Worker_GetRunningJobs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) GetRunningJobsStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RunningJobsStatsReply, error) {
+	out := new(RunningJobsStatsReply)
+	err := c.cc.Invoke(ctx, Worker_GetRunningJobsStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) Poll(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, Worker_Poll_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) ReducedStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*structpb.Struct, error) {
+	out := new(structpb.Struct)
+	err := c.cc.Invoke(ctx, Worker_ReducedStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) StartMeter(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Worker_StartMeter_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) StopMeter(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*StopMeterReply, error) {
+	out := new(StopMeterReply)
+	err := c.cc.Invoke(ctx, Worker_StopMeter_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) MeterLog(ctx context.Context, in *MeterLogRequest, opts ...grpc.CallOption) (Worker_MeterLogClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Worker_ServiceDesc.Streams[0], Worker_MeterLog_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &workerMeterLogClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Worker_MeterLogClient interface {
+	Recv() (*PowerReading, error)
+	grpc.ClientStream
+}
+
+type workerMeterLogClient struct {
+	grpc.ClientStream
+}
+
+func (x *workerMeterLogClient) Recv() (*PowerReading, error) {
+	m := new(PowerReading)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *workerClient) IsAvailable(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IsAvailableReply, error) {
+	out := new(IsAvailableReply)
+	err := c.cc.Invoke(ctx, Worker_IsAvailable_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) Drain(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IsAvailableReply, error) {
+	out := new(IsAvailableReply)
+	err := c.cc.Invoke(ctx, Worker_Drain_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) Undrain(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IsAvailableReply, error) {
+	out := new(IsAvailableReply)
+	err := c.cc.Invoke(ctx, Worker_Undrain_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) PowerMeterOn(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PowerMeterOnReply, error) {
+	out := new(PowerMeterOnReply)
+	err := c.cc.Invoke(ctx, Worker_PowerMeterOn_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) GetPowerModel(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PowerModel, error) {
+	out := new(PowerModel)
+	err := c.cc.Invoke(ctx, Worker_GetPowerModel_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) PowerCapActions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PowerCapActionsReply, error) {
+	out := new(PowerCapActionsReply)
+	err := c.cc.Invoke(ctx, Worker_PowerCapActions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) ListJobHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*JobHistoryReply, error) {
+	out := new(JobHistoryReply)
+	err := c.cc.Invoke(ctx, Worker_ListJobHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) JobStatus(ctx context.Context, in *JobStatusRequest, opts ...grpc.CallOption) (*JobStatusReply, error) {
+	out := new(JobStatusReply)
+	err := c.cc.Invoke(ctx, Worker_JobStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WorkerServer is the server API for Worker service.
+// All implementations must embed UnimplementedWorkerServer
+// for forward compatibility
+type WorkerServer interface {
+	StartJob(context.Context, *StartJobRequest) (*StartJobReply, error)
+	StopJob(context.Context, *StopJobRequest) (*StopJobReply, error)
+	GetRunningJobs(context.Context, *emptypb.Empty) (*RunningJobsReply, error)
+	GetRunningJobsStats(context.Context, *emptypb.Empty) (*RunningJobsStatsReply, error)
+	Poll(context.Context, *emptypb.Empty) (*structpb.Struct, error)
+	ReducedStats(context.Context, *emptypb.Empty) (*structpb.Struct, error)
+	StartMeter(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	StopMeter(context.Context, *emptypb.Empty) (*StopMeterReply, error)
+	MeterLog(*MeterLogRequest, Worker_MeterLogServer) error
+	IsAvailable(context.Context, *emptypb.Empty) (*IsAvailableReply, error)
+	Drain(context.Context, *emptypb.Empty) (*IsAvailableReply, error)
+	Undrain(context.Context, *emptypb.Empty) (*IsAvailableReply, error)
+	PowerMeterOn(context.Context, *emptypb.Empty) (*PowerMeterOnReply, error)
+	GetPowerModel(context.Context, *emptypb.Empty) (*PowerModel, error)
+	PowerCapActions(context.Context, *emptypb.Empty) (*PowerCapActionsReply, error)
+	ListJobHistory(context.Context, *HistoryRequest) (*JobHistoryReply, error)
+	JobStatus(context.Context, *JobStatusRequest) (*JobStatusReply, error)
+	mustEmbedUnimplementedWorkerServer()
+}
+
+// UnimplementedWorkerServer must be embedded to have forward compatible implementations.
+type UnimplementedWorkerServer struct {
+}
+
+func (UnimplementedWorkerServer) StartJob(context.Context, *StartJobRequest) (*StartJobReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartJob not implemented")
+}
+func (UnimplementedWorkerServer) StopJob(context.Context, *StopJobRequest) (*StopJobReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopJob not implemented")
+}
+func (UnimplementedWorkerServer) GetRunningJobs(context.Context, *emptypb.Empty) (*RunningJobsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRunningJobs not implemented")
+}
+func (UnimplementedWorkerServer) GetRunningJobsStats(context.Context, *emptypb.Empty) (*RunningJobsStatsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRunningJobsStats not implemented")
+}
+func (UnimplementedWorkerServer) Poll(context.Context, *emptypb.Empty) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Poll not implemented")
+}
+func (UnimplementedWorkerServer) ReducedStats(context.Context, *emptypb.Empty) (*structpb.Struct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReducedStats not implemented")
+}
+func (UnimplementedWorkerServer) StartMeter(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartMeter not implemented")
+}
+func (UnimplementedWorkerServer) StopMeter(context.Context, *emptypb.Empty) (*StopMeterReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopMeter not implemented")
+}
+func (UnimplementedWorkerServer) MeterLog(*MeterLogRequest, Worker_MeterLogServer) error {
+	return status.Errorf(codes.Unimplemented, "method MeterLog not implemented")
+}
+func (UnimplementedWorkerServer) IsAvailable(context.Context, *emptypb.Empty) (*IsAvailableReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsAvailable not implemented")
+}
+func (UnimplementedWorkerServer) Drain(context.Context, *emptypb.Empty) (*IsAvailableReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Drain not implemented")
+}
+func (UnimplementedWorkerServer) Undrain(context.Context, *emptypb.Empty) (*IsAvailableReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Undrain not implemented")
+}
+func (UnimplementedWorkerServer) PowerMeterOn(context.Context, *emptypb.Empty) (*PowerMeterOnReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PowerMeterOn not implemented")
+}
+func (UnimplementedWorkerServer) GetPowerModel(context.Context, *emptypb.Empty) (*PowerModel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPowerModel not implemented")
+}
+func (UnimplementedWorkerServer) PowerCapActions(context.Context, *emptypb.Empty) (*PowerCapActionsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PowerCapActions not implemented")
+}
+func (UnimplementedWorkerServer) ListJobHistory(context.Context, *HistoryRequest) (*JobHistoryReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobHistory not implemented")
+}
+func (UnimplementedWorkerServer) JobStatus(context.Context, *JobStatusRequest) (*JobStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JobStatus not implemented")
+}
+func (UnimplementedWorkerServer) mustEmbedUnimplementedWorkerServer() {}
+
+// UnsafeWorkerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WorkerServer will
+// result in compilation errors.
+type UnsafeWorkerServer interface {
+	mustEmbedUnimplementedWorkerServer()
+}
+
+func RegisterWorkerServer(s grpc.ServiceRegistrar, srv WorkerServer) {
+	s.RegisterService(&Worker_ServiceDesc, srv)
+}
+
+func _Worker_StartJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).StartJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Worker_StartJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).StartJob(ctx, req.(*StartJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_StopJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).StopJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Worker_StopJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).StopJob(ctx, req.(*StopJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_GetRunningJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).GetRunningJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Worker_GetRunningJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).GetRunningJobs(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_GetRunningJobsStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).GetRunningJobsStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Worker_GetRunningJobsStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).GetRunningJobsStats(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_Poll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).Poll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Worker_Poll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).Poll(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_ReducedStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).ReducedStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Worker_ReducedStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).ReducedStats(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_StartMeter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).StartMeter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Worker_StartMeter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).StartMeter(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_StopMeter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).StopMeter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Worker_StopMeter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).StopMeter(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_MeterLog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MeterLogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WorkerServer).MeterLog(m, &workerMeterLogServer{stream})
+}
+
+type Worker_MeterLogServer interface {
+	Send(*PowerReading) error
+	grpc.ServerStream
+}
+
+type workerMeterLogServer struct {
+	grpc.ServerStream
+}
+
+func (x *workerMeterLogServer) Send(m *PowerReading) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Worker_IsAvailable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).IsAvailable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Worker_IsAvailable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).IsAvailable(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_Drain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).Drain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Worker_Drain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).Drain(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_Undrain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).Undrain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Worker_Undrain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).Undrain(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_PowerMeterOn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).PowerMeterOn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Worker_PowerMeterOn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).PowerMeterOn(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_GetPowerModel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).GetPowerModel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Worker_GetPowerModel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).GetPowerModel(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_PowerCapActions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).PowerCapActions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Worker_PowerCapActions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).PowerCapActions(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_ListJobHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).ListJobHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Worker_ListJobHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).ListJobHistory(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_JobStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).JobStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Worker_JobStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).JobStatus(ctx, req.(*JobStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Worker_ServiceDesc is the grpc.ServiceDesc for Worker service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Worker_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "worker.Worker",
+	HandlerType: (*WorkerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StartJob",
+			Handler:    _Worker_StartJob_Handler,
+		},
+		{
+			MethodName: "StopJob",
+			Handler:    _Worker_StopJob_Handler,
+		},
+		{
+			MethodName: "GetRunningJobs",
+			Handler:    _Worker_GetRunningJobs_Handler,
+		},
+		{
+			MethodName: "GetRunningJobsStats",
+			Handler:    _Worker_GetRunningJobsStats_Handler,
+		},
+		{
+			MethodName: "Poll",
+			Handler:    _Worker_Poll_Handler,
+		},
+		{
+			MethodName: "ReducedStats",
+			Handler:    _Worker_ReducedStats_Handler,
+		},
+		{
+			MethodName: "StartMeter",
+			Handler:    _Worker_StartMeter_Handler,
+		},
+		{
+			MethodName: "StopMeter",
+			Handler:    _Worker_StopMeter_Handler,
+		},
+		{
+			MethodName: "IsAvailable",
+			Handler:    _Worker_IsAvailable_Handler,
+		},
+		{
+			MethodName: "Drain",
+			Handler:    _Worker_Drain_Handler,
+		},
+		{
+			MethodName: "Undrain",
+			Handler:    _Worker_Undrain_Handler,
+		},
+		{
+			MethodName: "PowerMeterOn",
+			Handler:    _Worker_PowerMeterOn_Handler,
+		},
+		{
+			MethodName: "GetPowerModel",
+			Handler:    _Worker_GetPowerModel_Handler,
+		},
+		{
+			MethodName: "PowerCapActions",
+			Handler:    _Worker_PowerCapActions_Handler,
+		},
+		{
+			MethodName: "ListJobHistory",
+			Handler:    _Worker_ListJobHistory_Handler,
+		},
+		{
+			MethodName: "JobStatus",
+			Handler:    _Worker_JobStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "MeterLog",
+			Handler:       _Worker_MeterLog_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "worker.proto",
+}